@@ -3,24 +3,130 @@ package assets
 import (
 	_ "embed"
 	"encoding/json"
+	"hash/fnv"
 	"math/rand"
+	"time"
 )
 
 //go:embed mantras.json
 var mantras []byte
 
+// MantraLoader picks mantras from a pool
 type MantraLoader struct {
 	mantras []string
+	random  *rand.Rand // nil uses the global source
 }
 
-func NewMantraLoader() (*MantraLoader, error) {
+// MantraRotation is the persisted state of a mantra rotation: every mantra of the
+// pool is shown once, in shuffled order, before the next cycle starts
+type MantraRotation struct {
+	Day    string   `json:"day,omitempty"`    // date (YYYY-MM-DD) Mantra was picked for
+	Mantra string   `json:"mantra,omitempty"` // the mantra of that day
+	Queue  []string `json:"queue,omitempty"`  // mantras left in the current cycle
+}
+
+// Mantras returns the built-in mantras
+func Mantras() ([]string, error) {
 	var m []string
 	if err := json.Unmarshal(mantras, &m); err != nil {
 		return nil, err
 	}
+	return m, nil
+}
+
+func NewMantraLoader() (*MantraLoader, error) {
+	m, err := Mantras()
+	if err != nil {
+		return nil, err
+	}
 	return &MantraLoader{mantras: m}, nil
 }
 
+// NewMantraLoaderFrom creates a loader picking from the given mantras, e.g. the
+// built-in ones merged with the user's packs
+func NewMantraLoaderFrom(mantras []string) *MantraLoader {
+	return &MantraLoader{mantras: mantras}
+}
+
+// SetRand sets the random source mantras are picked and shuffled with, e.g. a
+// seeded one in tests
+func (m *MantraLoader) SetRand(r *rand.Rand) {
+	m.random = r
+}
+
+// GetMantra picks a random mantra
 func (m *MantraLoader) GetMantra() string {
-	return m.mantras[rand.Intn(len(m.mantras))]
+	return m.mantras[m.intn(len(m.mantras))]
+}
+
+// MantraOfTheDay returns a mantra derived from the date of day and the pool only, so
+// it is the same for every reminder on that day
+func (m *MantraLoader) MantraOfTheDay(day time.Time) string {
+	if len(m.mantras) == 0 {
+		return ""
+	}
+	h := fnv.New64a()
+	h.Write([]byte(day.Format("2006-01-02")))
+	for _, mantra := range m.mantras {
+		h.Write([]byte{0})
+		h.Write([]byte(mantra))
+	}
+	return m.mantras[h.Sum64()%uint64(len(m.mantras))]
+}
+
+// Next returns the mantra of day from the rotation r, which is advanced on the first
+// call of a day. Mantras left out of the pool since are skipped, new ones join the
+// next cycle.
+func (m *MantraLoader) Next(r *MantraRotation, day time.Time) string {
+	if len(m.mantras) == 0 {
+		return ""
+	}
+	inPool := make(map[string]bool, len(m.mantras))
+	for _, mantra := range m.mantras {
+		inPool[mantra] = true
+	}
+	date := day.Format("2006-01-02")
+	if r.Day == date && inPool[r.Mantra] {
+		return r.Mantra
+	}
+
+	var queue []string
+	for _, mantra := range r.Queue {
+		if inPool[mantra] {
+			queue = append(queue, mantra)
+		}
+	}
+	if len(queue) == 0 {
+		queue = m.shuffled()
+		// a new cycle doesn't start with the mantra the last one ended with
+		if len(queue) > 1 && queue[0] == r.Mantra {
+			queue[0], queue[len(queue)-1] = queue[len(queue)-1], queue[0]
+		}
+	}
+	r.Day, r.Mantra, r.Queue = date, queue[0], queue[1:]
+	return r.Mantra
+}
+
+// shuffled returns the mantras in random order, without duplicates
+func (m *MantraLoader) shuffled() []string {
+	seen := make(map[string]bool, len(m.mantras))
+	var out []string
+	for _, mantra := range m.mantras {
+		if !seen[mantra] {
+			seen[mantra] = true
+			out = append(out, mantra)
+		}
+	}
+	for i := len(out) - 1; i > 0; i-- {
+		j := m.intn(i + 1)
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+func (m *MantraLoader) intn(n int) int {
+	if m.random != nil {
+		return m.random.Intn(n)
+	}
+	return rand.Intn(n)
 }
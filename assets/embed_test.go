@@ -0,0 +1,122 @@
+package assets
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func testLoader(mantras ...string) *MantraLoader {
+	m := NewMantraLoaderFrom(mantras)
+	m.SetRand(rand.New(rand.NewSource(1)))
+	return m
+}
+
+func day(d int) time.Time {
+	return time.Date(2026, 10, d, 16, 0, 0, 0, time.UTC)
+}
+
+func TestMantras(t *testing.T) {
+	mantras, err := Mantras()
+	if err != nil {
+		t.Fatalf("Failed to load mantras: %v", err)
+	}
+	if len(mantras) == 0 {
+		t.Fatal("Expected built-in mantras")
+	}
+	for _, m := range mantras {
+		if m == "" {
+			t.Error("Expected no empty mantras")
+		}
+	}
+}
+
+func TestGetMantraWithRand(t *testing.T) {
+	pool := []string{"a", "b", "c", "d"}
+	first, second := testLoader(pool...), testLoader(pool...)
+	for i := 0; i < 10; i++ {
+		if a, b := first.GetMantra(), second.GetMantra(); a != b {
+			t.Fatalf("Expected the same picks from the same source, got %s and %s", a, b)
+		}
+	}
+}
+
+func TestMantraOfTheDay(t *testing.T) {
+	m := NewMantraLoaderFrom([]string{"a", "b", "c", "d", "e", "f", "g"})
+
+	today := m.MantraOfTheDay(day(16))
+	if got := m.MantraOfTheDay(time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)); got != today {
+		t.Errorf("Expected the same mantra all day, got %s and %s", today, got)
+	}
+
+	seen := make(map[string]bool)
+	for d := 1; d <= 31; d++ {
+		seen[m.MantraOfTheDay(day(d))] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("Expected the mantra to change between days, got %v", seen)
+	}
+
+	if got := NewMantraLoaderFrom(nil).MantraOfTheDay(day(16)); got != "" {
+		t.Errorf("Expected no mantra from an empty pool, got %s", got)
+	}
+}
+
+func TestNextCyclesWithoutRepeats(t *testing.T) {
+	pool := []string{"a", "b", "c", "d", "e"}
+	m := testLoader(pool...)
+	var r MantraRotation
+
+	var shown []string
+	for d := 1; d <= 10; d++ {
+		mantra := m.Next(&r, day(d))
+		if again := m.Next(&r, day(d).Add(2*time.Hour)); again != mantra {
+			t.Errorf("Day %d: expected the same mantra all day, got %s and %s", d, mantra, again)
+		}
+		shown = append(shown, mantra)
+	}
+
+	for _, cycle := range [][]string{shown[:5], shown[5:]} {
+		sorted := slices.Sorted(slices.Values(cycle))
+		if !slices.Equal(sorted, pool) {
+			t.Errorf("Expected every mantra once per cycle, got %v", cycle)
+		}
+	}
+	if shown[4] == shown[5] {
+		t.Errorf("Expected a new cycle not to start with the last mantra, got %v", shown)
+	}
+}
+
+func TestNextFollowsPoolChanges(t *testing.T) {
+	r := MantraRotation{Day: "2026-10-15", Mantra: "a", Queue: []string{"b", "removed", "c"}}
+
+	m := testLoader("a", "b", "c", "new")
+	if got := m.Next(&r, day(15)); got != "a" {
+		t.Errorf("Expected the mantra of the day to stay, got %s", got)
+	}
+	if got := m.Next(&r, day(16)); got != "b" {
+		t.Errorf("Expected b, got %s", got)
+	}
+	if got := m.Next(&r, day(17)); got != "c" {
+		t.Errorf("Expected removed mantras to be skipped, got %s", got)
+	}
+	if len(r.Queue) != 0 {
+		t.Errorf("Expected an empty queue, got %v", r.Queue)
+	}
+	// the new mantra joins the next cycle
+	next := m.Next(&r, day(18))
+	if len(r.Queue) != 3 || !slices.Contains(append(r.Queue, next), "new") {
+		t.Errorf("Expected a new cycle with the new mantra, got %s then %v", next, r.Queue)
+	}
+
+	// the mantra of the day was removed
+	r = MantraRotation{Day: "2026-10-16", Mantra: "gone", Queue: []string{"b"}}
+	if got := m.Next(&r, day(16)); got != "b" {
+		t.Errorf("Expected a removed mantra of the day to be replaced, got %s", got)
+	}
+
+	if got := NewMantraLoaderFrom(nil).Next(&r, day(19)); got != "" {
+		t.Errorf("Expected no mantra from an empty pool, got %s", got)
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sultengutt/internal/calendar"
+	"sultengutt/internal/config"
+	"time"
+)
+
+const meetingTimeFormat = "15:04"
+
+// deferral returns when a reminder due at now is shown instead because it falls into
+// a meeting: the start of the next free gap before the order cutoff. It reports
+// false when the reminder should be shown now, also when there is no gap in time.
+func deferral(cfg config.Config, cal *calendar.Calendar, now time.Time) (time.Time, calendar.Event, bool) {
+	if cal == nil {
+		return time.Time{}, calendar.Event{}, false
+	}
+	busy, ok := cal.BusyAt(now)
+	if !ok {
+		return time.Time{}, calendar.Event{}, false
+	}
+	limit := now.Add(config.DefaultDeferLimit)
+	if cutoff, ok := cfg.Cutoff(now); ok {
+		limit = cutoff
+	}
+	free, ok := cal.NextFree(now, limit, cfg.Calendar.Gap())
+	if !ok {
+		return time.Time{}, busy, false
+	}
+	return free, busy, true
+}
+
+func runCalendarShow(cfg config.Config, now time.Time) error {
+	if cfg.Calendar.Path == "" {
+		fmt.Println("No calendar. Use 'sultengutt calendar set' to avoid showing the reminder in meetings.")
+		return nil
+	}
+	fmt.Printf("Calendar: %s\n", cfg.Calendar.Path)
+	fmt.Printf("Free time needed: %d minutes\n", int(cfg.Calendar.Gap().Minutes()))
+	if _, ok := cfg.Cutoff(now); ok {
+		fmt.Printf("Order cutoff: %s\n", cfg.OrderCutoff)
+	} else {
+		fmt.Printf("Order cutoff: none, reminders wait up to %d hours\n", int(config.DefaultDeferLimit.Hours()))
+	}
+	printCutoffWarning(cfg)
+
+	cal, err := cfg.BusyCalendar()
+	if err != nil {
+		return err
+	}
+	y, m, d := now.Date()
+	endOfDay := time.Date(y, m, d+1, 0, 0, 0, 0, now.Location())
+	var meetings []string
+	for _, e := range cal.Occurrences(now, endOfDay) {
+		if !e.Free {
+			meetings = append(meetings, fmt.Sprintf("  %s–%s  %s",
+				e.Start.In(now.Location()).Format(meetingTimeFormat), e.End().In(now.Location()).Format(meetingTimeFormat), e.Summary))
+		}
+	}
+	if len(meetings) == 0 {
+		fmt.Println("\nNo more meetings today")
+	} else {
+		fmt.Println("\nMeetings left today:")
+		fmt.Println(strings.Join(meetings, "\n"))
+	}
+	if len(cal.Unsupported) > 0 {
+		fmt.Println(infoStyle.Render("\nOnly the first occurrence is known of: " + strings.Join(cal.Unsupported, ", ")))
+	}
+	return nil
+}
+
+func runCalendarSet(cfg *config.Config, path string, gapMinutes int) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve calendar path: %w", err)
+	}
+	if _, err := calendar.Load(abs, time.Local); err != nil {
+		return err
+	}
+	if err := cfg.SetCalendar(config.CalendarSettings{Path: abs, MinGapMinutes: gapMinutes}); err != nil {
+		return err
+	}
+	fmt.Println(successStyle.Render("✓ Reminders falling into meetings of " + abs + " wait for a free gap"))
+	return nil
+}
+
+func runCalendarOff(cfg *config.Config) {
+	cfg.Calendar = config.CalendarSettings{}
+	fmt.Println(infoStyle.Render("Reminders no longer wait for meetings to end"))
+}
+
+// printCutoffWarning tells when the order cutoff is ignored because the reminder
+// time has moved past it
+func printCutoffWarning(cfg config.Config) {
+	if err := cfg.CheckOrderCutoff(); err != nil {
+		fmt.Println(errorStyle.Render(fmt.Sprintf("The order cutoff is ignored: %v. Use 'sultengutt cutoff' to change it.", err)))
+	}
+}
+
+func runCutoff(cfg *config.Config, value string) error {
+	if value == "off" {
+		cfg.OrderCutoff = ""
+		fmt.Println(infoStyle.Render("Order cutoff removed"))
+		return nil
+	}
+	if value == "" {
+		return errors.New("give a time like 15:30, or off")
+	}
+	if err := cfg.SetOrderCutoff(value); err != nil {
+		return err
+	}
+	fmt.Println(successStyle.Render("✓ Order cutoff set to " + value))
+	return nil
+}
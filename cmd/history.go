@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sultengutt/internal/config"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// commandLine is how a command is recorded in the config history: its path, the flags
+// it was given and its arguments, quoted where needed so it can be run again
+func commandLine(cmd *cobra.Command, args []string) string {
+	parts := []string{cmd.CommandPath()}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if f.Value.Type() == "bool" && f.Value.String() == "true" {
+			parts = append(parts, "--"+f.Name)
+			return
+		}
+		parts = append(parts, "--"+f.Name+"="+quoteArg(f.Value.String()))
+	})
+	for _, arg := range args {
+		parts = append(parts, quoteArg(arg))
+	}
+	return strings.Join(parts, " ")
+}
+
+func quoteArg(arg string) string {
+	if arg == "" || strings.ContainsAny(arg, " \t\n\"'") {
+		return strconv.Quote(arg)
+	}
+	return arg
+}
+
+func runConfigHistory(cm *config.ConfigManager) error {
+	entries, err := cm.History()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println(infoStyle.Render("No configuration history yet"))
+		return nil
+	}
+
+	// newest first, numbered by how many undo steps it takes to get back to it
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		steps := len(entries) - 1 - i
+
+		label := fmt.Sprintf("[%d]", steps)
+		if steps == 0 {
+			label = "[current]"
+		}
+		command := entry.Command
+		if command == "" {
+			command = "unknown command"
+		}
+		fmt.Printf("%s %s  %s\n", infoStyle.Render(label),
+			time.Unix(entry.Timestamp, 0).Format("2006-01-02 15:04"), command)
+
+		var before []byte
+		if i > 0 {
+			before = entries[i-1].Config
+		}
+		lines, err := config.DiffVersions(before, entry.Config)
+		if err != nil {
+			return err
+		}
+		for _, line := range lines {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				fmt.Println("    " + successStyle.Render(line))
+			case strings.HasPrefix(line, "-"):
+				fmt.Println("    " + errorStyle.Render(line))
+			}
+		}
+		fmt.Println()
+	}
+	fmt.Println("tip: use 'sultengutt config undo [n]' to restore version [n]")
+	return nil
+}
+
+func runConfigUndo(cfg *config.Config, cm *config.ConfigManager, steps int) error {
+	previous := cfg.InstallOptions
+	if err := cm.Undo(cfg, steps); err != nil {
+		return fmt.Errorf("failed to undo configuration: %w", err)
+	}
+
+	if err := syncTask(cfg.InstallOptions, cm.ConfigDir(), true); err != nil {
+		// put the schedule back the way it was so the task and config don't disagree
+		cfg.InstallOptions = previous
+		if saveErr := cm.SaveWithHistory(cfg); saveErr != nil {
+			return fmt.Errorf("%w (and failed to restore config: %v)", err, saveErr)
+		}
+		return err
+	}
+
+	fmt.Println(successStyle.Render(fmt.Sprintf("✓ Restored configuration from %d change(s) ago", steps)))
+	return nil
+}
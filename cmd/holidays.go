@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sultengutt/internal/config"
+	"sultengutt/internal/holidays"
+	"time"
+)
+
+const holidayDateFormat = "Monday, January 2, 2006"
+
+// nextSkippedHoliday returns the next holiday that falls on one of the scheduled days
+func nextSkippedHoliday(cfg config.Config, now time.Time) (holidays.Holiday, bool) {
+	cal, err := cfg.HolidayCalendar()
+	if err != nil {
+		return holidays.Holiday{}, false
+	}
+	return cal.Next(now, func(h holidays.Holiday) bool {
+		return slices.Contains(cfg.InstallOptions.Days, h.Date.Weekday().String())
+	})
+}
+
+func runHolidaysList(cfg config.Config, year int) error {
+	cal, err := cfg.HolidayCalendar()
+	if err != nil {
+		return err
+	}
+
+	regions := cfg.HolidayRegions()
+	if len(regions) == 0 {
+		fmt.Println("Regions: none")
+	} else {
+		fmt.Println("Regions: " + strings.Join(regions, ", "))
+	}
+	fmt.Printf("Holidays in %d:\n", year)
+
+	days := cal.ForYear(year)
+	if len(days) == 0 {
+		fmt.Println("  none")
+		return nil
+	}
+	for _, h := range days {
+		line := fmt.Sprintf("  %s  %s", h.Date.Format("Mon 2006-01-02"), h.Name)
+		if slices.Contains(cfg.InstallOptions.Days, h.Date.Weekday().String()) {
+			line += infoStyle.Render(" (reminder skipped)")
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func runHolidaysAdd(cfg *config.Config, date, name string) error {
+	if err := cfg.AddCustomHoliday(date, name); err != nil {
+		return err
+	}
+	fmt.Println(successStyle.Render("✓ Added holiday on " + date))
+	return nil
+}
+
+func runHolidaysRemove(cfg *config.Config, date string) error {
+	if err := cfg.RemoveCustomHoliday(date); err != nil {
+		return err
+	}
+	fmt.Println(infoStyle.Render("Removed holiday on " + date))
+	return nil
+}
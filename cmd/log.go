@@ -0,0 +1,21 @@
+package main
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+const logFile = "sultengutt.log"
+
+// openLog opens the log file in the config directory for appending. Scheduled runs
+// have nowhere to print, so this is where they report what they did. Logging is
+// best effort: if the file can't be opened, messages are discarded.
+func openLog(configDir string) (*log.Logger, func()) {
+	f, err := os.OpenFile(filepath.Join(configDir, logFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return log.New(io.Discard, "", 0), func() {}
+	}
+	return log.New(f, "", log.LstdFlags), func() { f.Close() }
+}
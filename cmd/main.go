@@ -3,11 +3,17 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"log"
 	"os"
+	"strconv"
 	"strings"
 	"sultengutt/internal/config"
+	"sultengutt/internal/holidays"
 	"sultengutt/internal/installer"
+	"sultengutt/internal/notify"
+	"sultengutt/internal/opener"
 	"sultengutt/internal/popup"
+	"sultengutt/internal/popup/model"
 	"sultengutt/internal/scheduler"
 	"sultengutt/internal/utils"
 	"time"
@@ -52,9 +58,14 @@ func main() {
 			"Be reminded to order your surprise dinner on your schedule! Never be hungry again 🍔",
 		Example: `  sultengutt install
   sultengutt execute
+  sultengutt execute --tui
   sultengutt pause 1 day
   sultengutt resume
   sultengutt status`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// record the full command line so config history can show what caused a change
+			cm.SetCommand(commandLine(cmd, args))
+		},
 	}
 
 	installCmd := &cobra.Command{
@@ -69,35 +80,200 @@ func main() {
 	executeCmd := &cobra.Command{
 		Use:   "execute",
 		Short: "Execute Sultengutt reminder",
-		Long:  "Executes Sultengutt to trigger the popup reminder.",
+		Long: `Executes Sultengutt to trigger the popup reminder.
+
+The configured notifiers are tried in order until one of them delivers the
+reminder, so without a display (e.g. over SSH) it falls back to the terminal.
+Use --tui to always show it in the terminal, or --notify to show it as a
+desktop notification with Order and Snooze buttons.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// how many times this reminder has been shown, counting re-fires after timeouts
+			attempt := 1
+			if snoozed, _ := cmd.Flags().GetBool("snoozed"); snoozed {
+				at, _ := cmd.Flags().GetInt64("at")
+				if !snoozeDue(*cfg, at, time.Now()) {
+					// cancelled with 'sultengutt unsnooze' or snoozed again since
+					return nil
+				}
+				attempt = cfg.CurrentAttempt()
+				cfg.Unsnooze()
+				if err := cm.Save(cfg); err != nil {
+					return fmt.Errorf("failed to save config: %w", err)
+				}
+			}
 			if cfg.IsPaused() && cfg.PausedUntil == 0 || cfg.IsPaused() && cfg.PausedUntil > 0 && time.Now().Unix() < cfg.PausedUntil {
 				fmt.Println("paused. Use 'sultengutt resume' to unpause.")
 				return nil
 			}
 			// check if we need to resume
 			if cfg.IsPaused() && cfg.PausedUntil > 0 && time.Now().Unix() >= cfg.PausedUntil {
-				cfg.PausedUntil = -1
+				event := config.PauseEvent{
+					Timestamp: time.Now().Unix(),
+					Action:    config.PauseActionAutoResume,
+					User:      currentUser(),
+					Reason:    cfg.PauseReason,
+				}
+				cfg.Resume()
 				err := cm.Save(cfg)
 				if err != nil {
 					return fmt.Errorf("failed to save config: %w", err)
 				}
+				if err := cm.RecordPauseEvent(event); err != nil {
+					return fmt.Errorf("failed to record resume: %w", err)
+				}
+			}
+			now := time.Now()
+			if cfg.PruneExpiredPauseWindows(now) {
+				if err := cm.Save(cfg); err != nil {
+					return fmt.Errorf("failed to save config: %w", err)
+				}
+			}
+			if w, ok := cfg.ActivePauseWindow(now); ok {
+				fmt.Printf("paused by planned pause %d until %s. Use 'sultengutt pause rm %d' to cancel it.\n",
+					w.ID, time.Unix(w.To, 0).Format(windowTimeFormat), w.ID)
+				return nil
+			}
+			cfg.PruneSkips(now)
+			if cfg.IsSkipped(now) {
+				// the skip is used up once the reminder it was meant for has been skipped
+				cfg.RemoveSkip(now.Format("2006-01-02"))
+				if err := cm.Save(cfg); err != nil {
+					return fmt.Errorf("failed to save config: %w", err)
+				}
+				fmt.Println("skipped. Use 'sultengutt skip' to manage skipped reminders.")
+				return nil
+			}
+			cal, err := cfg.HolidayCalendar()
+			if err != nil {
+				return fmt.Errorf("failed to load holidays: %w", err)
+			}
+			if h, ok := cal.HolidayOn(now); ok {
+				logger, closeLog := openLog(cm.ConfigDir())
+				defer closeLog()
+				logger.Printf("skipped reminder: %s is a holiday (%s)", h.Date.Format("2006-01-02"), h.Name)
+				return nil
 			}
 
-			popup.ShowPopup(cfg.InstallOptions.SiteLink)
+			logger, closeLog := openLog(cm.ConfigDir())
+			defer closeLog()
+			meetings, err := cfg.BusyCalendar()
+			if err != nil {
+				// a broken calendar must not cost the reminder
+				logger.Printf("failed to read calendar: %v", err)
+			}
+			if until, busy, ok := deferral(*cfg, meetings, now); ok {
+				snoozer, err := scheduler.NewSnoozer(cm.ConfigDir())
+				if err != nil {
+					logger.Printf("failed to defer reminder: %v", err)
+					return err
+				}
+				until, err = runSnooze(cfg, snoozer, until.Sub(now), now)
+				if err != nil {
+					logger.Printf("failed to defer reminder: %v", err)
+					return err
+				}
+				cfg.State.RefireAttempt = attempt
+				logger.Printf("deferred reminder to %s: busy with %s", until.Format(snoozeTimeFormat), busy.Summary)
+				return cm.Save(cfg)
+			} else if !busy.Start.IsZero() {
+				logger.Printf("no free time before the cutoff, showing the reminder during %s", busy.Summary)
+			}
+
+			order := cfg.NotifierOrder()
+			if names, _ := cmd.Flags().GetStringSlice("notifier"); len(names) > 0 {
+				order = names
+			}
+			if useNotification, _ := cmd.Flags().GetBool("notify"); useNotification {
+				order = []string{config.NotifierNotification}
+			}
+			if useTUI, _ := cmd.Flags().GetBool("tui"); useTUI {
+				order = []string{config.NotifierTerminal}
+			}
+
+			popup.SetOpener(opener.New(cfg.Browser))
+			mantra, err := todaysMantra(cm, cfg, now)
+			if err != nil {
+				logger.Printf("failed to pick the mantra: %v", err)
+			}
+			m := reminderModel(*cfg, mantra, now, logger)
+			if cfg.Refire.Enabled() {
+				m.Attempt, m.Attempts = attempt, cfg.Refire.Attempts()
+			}
+			outcome, err := notify.Deliver(notify.Build(cfg.Notifiers, order, logger), m, logger)
+			if err != nil {
+				return err
+			}
+			if err := recordOutcome(cm, outcome, time.Now()); err != nil {
+				logger.Printf("failed to record outcome: %v", err)
+			}
+			if outcome.Action == model.ActionOrder && outcome.Vendor != "" {
+				// the vendor ordered from is preselected next time
+				cfg.SetLastVendor(outcome.Vendor)
+				if err := cm.Save(cfg); err != nil {
+					logger.Printf("failed to save last vendor: %v", err)
+				}
+			}
 
+			if outcome.Action == model.ActionTimeout {
+				if at, ok := cfg.NextRefire(attempt, time.Now()); ok {
+					snoozer, err := scheduler.NewSnoozer(cm.ConfigDir())
+					if err != nil {
+						logger.Printf("failed to re-fire reminder: %v", err)
+						return err
+					}
+					until, err := runRefire(cfg, snoozer, at, attempt+1, time.Now())
+					if err != nil {
+						logger.Printf("failed to re-fire reminder: %v", err)
+						return err
+					}
+					logger.Printf("nobody answered, showing the reminder again at %s (reminder %d of %d)",
+						until.Format(snoozeTimeFormat), attempt+1, cfg.Refire.Attempts())
+					return cm.Save(cfg)
+				}
+			}
+			if outcome.Action == model.ActionSnooze && outcome.SnoozeFor > 0 {
+				snoozer, err := scheduler.NewSnoozer(cm.ConfigDir())
+				if err != nil {
+					logger.Printf("failed to snooze: %v", err)
+					return err
+				}
+				until, err := runSnooze(cfg, snoozer, outcome.SnoozeFor, time.Now())
+				if err != nil {
+					logger.Printf("failed to snooze: %v", err)
+					return err
+				}
+				logger.Printf("snoozed until %s", until.Format(snoozeTimeFormat))
+				return cm.Save(cfg)
+			}
 			return nil
 		},
 	}
+	executeCmd.Flags().Bool("tui", false, "Show the reminder in the terminal instead of a popup")
+	executeCmd.Flags().Bool("notify", false, "Show the reminder as a desktop notification instead of a popup (Linux)")
+	executeCmd.Flags().StringSlice("notifier", nil, "Notifiers to try in order, overriding the configured order")
+	executeCmd.Flags().Bool("snoozed", false, "Show a snoozed reminder, if it is still snoozed")
+	executeCmd.Flags().Int64("at", 0, "The unix time the snoozed reminder was scheduled for")
+	executeCmd.Flags().MarkHidden("snoozed")
+	executeCmd.Flags().MarkHidden("at")
 
 	pauseCmd := &cobra.Command{
 		Use:   "pause",
 		Short: "Pause the Sultengutt reminder. Use -help for full examples",
-		Long:  "Pause Sultengutt for a period of time or until you resume\n\nAllowed units: day(s), week(s), month(s) or indefinitely (no arguments) ",
+		Long:  "Pause Sultengutt for a period of time, until a date or until you resume\n\nAllowed units: minute(s), hour(s), day(s), week(s), month(s), combined (1 week 3 days, 2w3d), ISO-8601 (P1W3D) or indefinitely (no arguments)\nPauses shorter than a day last exactly that long, longer pauses end at your reminder time\nAllowed dates: YYYY-MM-DD, YYYY-MM-DD HH:MM, a weekday, tomorrow or next week",
 		Example: `sultengutt pause 1 day
 	sultengutt pause 4 weeks
 	sultengutt pause 1 month
-	sultengutt pause // Pause indefinitely`,
+	sultengutt pause 1 week 3 days
+	sultengutt pause 30m
+	sultengutt pause until 2026-12-27
+	sultengutt pause until monday
+	sultengutt pause until next week
+	sultengutt pause until 2026-12-27 15:30
+	sultengutt pause // Pause indefinitely
+	sultengutt pause 2 weeks --reason diet
+	sultengutt pause add --from 2026-12-21 --to 2027-01-03 --reason christmas
+	sultengutt pause list
+	sultengutt pause rm 1`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if cfg.IsFreshInstall() {
 				fmt.Println(errorStyle.Render("Sultengutt has not been installed yet, please run `sultengutt install` first"))
@@ -106,20 +282,137 @@ func main() {
 			if err := runPause(args, cfg); err != nil {
 				return err
 			}
-			return cm.Save(cfg)
+			cfg.PauseReason, _ = cmd.Flags().GetString("reason")
+			if err := cm.SaveWithHistory(cfg); err != nil {
+				return err
+			}
+			return cm.RecordPauseEvent(config.PauseEvent{
+				Timestamp: time.Now().Unix(),
+				Action:    config.PauseActionPause,
+				User:      currentUser(),
+				Reason:    cfg.PauseReason,
+				Until:     cfg.PausedUntil,
+			})
 		},
 	}
+	pauseCmd.Flags().String("reason", "", "Why you are pausing, e.g. vacation, diet or office closed")
+
+	pauseAddCmd := &cobra.Command{
+		Use:   "add",
+		Short: "Plan a pause between two dates",
+		Long:  "Plan a pause, e.g. a vacation, between two dates (YYYY-MM-DD or YYYY-MM-DD HH:MM).\nDates without a time cover the whole day.",
+		Example: `  sultengutt pause add --from 2026-12-21 --to 2027-01-03 --reason christmas
+  sultengutt pause add --from "2026-02-16 12:00" --to 2026-02-20`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.IsFreshInstall() {
+				fmt.Println(errorStyle.Render("Sultengutt has not been installed yet, please run `sultengutt install` first"))
+				return nil
+			}
+			from, _ := cmd.Flags().GetString("from")
+			to, _ := cmd.Flags().GetString("to")
+			reason, _ := cmd.Flags().GetString("reason")
+			now := time.Now()
+			cfg.PruneExpiredPauseWindows(now)
+			w, err := runPauseAdd(cfg, from, to, reason, now)
+			if err != nil {
+				return err
+			}
+			if err := cm.SaveWithHistory(cfg); err != nil {
+				return err
+			}
+			return cm.RecordPauseEvent(pauseWindowEvent(config.PauseActionPlan, w, now))
+		},
+	}
+	pauseAddCmd.Flags().String("from", "", "First day of the pause (YYYY-MM-DD [HH:MM])")
+	pauseAddCmd.Flags().String("to", "", "Last day of the pause (YYYY-MM-DD [HH:MM])")
+	pauseAddCmd.Flags().String("reason", "", "Why you are away, e.g. vacation")
+	pauseAddCmd.MarkFlagRequired("from")
+	pauseAddCmd.MarkFlagRequired("to")
+
+	pauseListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List planned pauses",
+		Long:  "List planned pauses that have not ended yet.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.IsFreshInstall() {
+				fmt.Println(errorStyle.Render("Sultengutt has not been installed yet, please run `sultengutt install` first"))
+				return nil
+			}
+			if cfg.PruneExpiredPauseWindows(time.Now()) {
+				if err := cm.Save(cfg); err != nil {
+					return fmt.Errorf("failed to save config: %w", err)
+				}
+			}
+			runPauseList(*cfg)
+			return nil
+		},
+	}
+
+	pauseRmCmd := &cobra.Command{
+		Use:     "rm ID",
+		Short:   "Remove a planned pause",
+		Long:    "Remove a planned pause by the ID shown in 'sultengutt pause list'.",
+		Example: `  sultengutt pause rm 2`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.IsFreshInstall() {
+				fmt.Println(errorStyle.Render("Sultengutt has not been installed yet, please run `sultengutt install` first"))
+				return nil
+			}
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid ID: %s", args[0])
+			}
+			w, err := runPauseRemove(cfg, id)
+			if err != nil {
+				return err
+			}
+			if err := cm.SaveWithHistory(cfg); err != nil {
+				return err
+			}
+			return cm.RecordPauseEvent(pauseWindowEvent(config.PauseActionUnplan, w, time.Now()))
+		},
+	}
+	pauseLogCmd := &cobra.Command{
+		Use:   "log",
+		Short: "Show who paused and resumed reminders, and why",
+		Long:  "Show the history of pauses, resumes, automatic resumes and planned pauses.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			limit, _ := cmd.Flags().GetInt("limit")
+			return runPauseLog(cm, limit)
+		},
+	}
+	pauseLogCmd.Flags().IntP("limit", "n", 20, "Number of most recent events to show (0 for all)")
+
+	pauseCmd.AddCommand(pauseAddCmd, pauseListCmd, pauseRmCmd, pauseLogCmd)
 
 	resumeCmd := &cobra.Command{
 		Use:   "resume",
 		Short: "Resume Sultengutt reminders",
 		Long:  "Manually resume Sultengutt reminders.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg.PausedUntil = -1
-			err := cm.Save(cfg)
+			if cfg.IsFreshInstall() {
+				fmt.Println(errorStyle.Render("Sultengutt has not been installed yet, please run `sultengutt install` first"))
+				return nil
+			}
+			reason := cfg.PauseReason
+			cfg.Resume()
+			err := cm.SaveWithHistory(cfg)
 			if err != nil {
 				return fmt.Errorf("failed to save config: %w", err)
 			}
+			err = cm.RecordPauseEvent(config.PauseEvent{
+				Timestamp: time.Now().Unix(),
+				Action:    config.PauseActionResume,
+				User:      currentUser(),
+				Reason:    reason,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to record resume: %w", err)
+			}
 			fmt.Println(infoStyle.Render("Resumed Sultengutt reminders"))
 			return nil
 		},
@@ -135,6 +428,10 @@ func main() {
 				return
 			}
 			runStatus(*cfg)
+			if last, ok, err := cm.LastReminder(); err == nil && ok {
+				fmt.Println()
+				fmt.Println("Last reminder: " + formatReminderOutcome(last))
+			}
 		},
 	}
 
@@ -151,7 +448,596 @@ func main() {
 	}
 	uninstallCmd.Flags().Bool("confirm", false, "Skip confirmation prompt")
 
-	rootCmd.AddCommand(installCmd, executeCmd, pauseCmd, resumeCmd, statusCmd, uninstallCmd)
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect and restore Sultengutt configuration",
+		Long:  "Inspect the configuration history and restore earlier versions.",
+	}
+
+	configHistoryCmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show previous configuration versions",
+		Long:  "Show previous configuration versions with the changes made by each command.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigHistory(cm)
+		},
+	}
+
+	configUndoCmd := &cobra.Command{
+		Use:   "undo [n]",
+		Short: "Restore an earlier configuration version",
+		Long:  "Undo the last n configuration changes (default 1) and update the scheduled task.",
+		Example: `  sultengutt config undo
+  sultengutt config undo 3`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.IsFreshInstall() {
+				fmt.Println(errorStyle.Render("Sultengutt has not been installed yet, please run `sultengutt install` first"))
+				return nil
+			}
+			steps := 1
+			if len(args) == 1 {
+				n, err := strconv.Atoi(args[0])
+				if err != nil || n < 1 {
+					return fmt.Errorf("invalid number of changes: %s", args[0])
+				}
+				steps = n
+			}
+			return runConfigUndo(cfg, cm, steps)
+		},
+	}
+	configCmd.AddCommand(configHistoryCmd, configUndoCmd)
+
+	skipCmd := &cobra.Command{
+		Use:   "skip DATE",
+		Short: "Skip upcoming reminders",
+		Long:  "Skip the reminder on a given date (YYYY-MM-DD), or the next N reminders with 'skip next'.",
+		Example: `  sultengutt skip next
+  sultengutt skip next 2
+  sultengutt skip 2026-10-16
+  sultengutt skip rm 2026-10-16`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.IsFreshInstall() {
+				fmt.Println(errorStyle.Render("Sultengutt has not been installed yet, please run `sultengutt install` first"))
+				return nil
+			}
+			if err := runSkipDate(cfg, args[0], time.Now()); err != nil {
+				return err
+			}
+			return cm.SaveWithHistory(cfg)
+		},
+	}
+
+	skipNextCmd := &cobra.Command{
+		Use:   "next [N]",
+		Short: "Skip the next N reminders",
+		Long:  "Skip the next N reminders (default 1) that would otherwise fire.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.IsFreshInstall() {
+				fmt.Println(errorStyle.Render("Sultengutt has not been installed yet, please run `sultengutt install` first"))
+				return nil
+			}
+			n := 1
+			if len(args) == 1 {
+				v, err := strconv.Atoi(args[0])
+				if err != nil {
+					return fmt.Errorf("invalid number of reminders: %s", args[0])
+				}
+				n = v
+			}
+			now := time.Now()
+			cfg.PruneSkips(now)
+			if err := runSkipNext(cfg, n, now); err != nil {
+				return err
+			}
+			return cm.SaveWithHistory(cfg)
+		},
+	}
+
+	skipRmCmd := &cobra.Command{
+		Use:   "rm DATE",
+		Short: "Stop skipping the reminder on a date",
+		Long:  "Stop skipping the reminder on a date (YYYY-MM-DD).",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.IsFreshInstall() {
+				fmt.Println(errorStyle.Render("Sultengutt has not been installed yet, please run `sultengutt install` first"))
+				return nil
+			}
+			if err := runSkipRemove(cfg, args[0]); err != nil {
+				return err
+			}
+			return cm.SaveWithHistory(cfg)
+		},
+	}
+	skipCmd.AddCommand(skipNextCmd, skipRmCmd)
+
+	holidaysCmd := &cobra.Command{
+		Use:   "holidays",
+		Short: "Manage days when reminders are skipped",
+		Long:  "Reminders are skipped on public holidays of the configured regions and on custom dates.",
+	}
+
+	holidaysListCmd := &cobra.Command{
+		Use:   "list [year]",
+		Short: "List holidays",
+		Long:  "List public and custom holidays for a year (default: this year).",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			year := time.Now().Year()
+			if len(args) == 1 {
+				y, err := strconv.Atoi(args[0])
+				if err != nil {
+					return fmt.Errorf("invalid year: %s", args[0])
+				}
+				year = y
+			}
+			return runHolidaysList(*cfg, year)
+		},
+	}
+
+	holidaysAddCmd := &cobra.Command{
+		Use:   "add DATE [NAME]",
+		Short: "Add a custom holiday",
+		Long:  "Add a custom day off, either a single date (YYYY-MM-DD) or a date repeating every year (MM-DD).",
+		Example: `  sultengutt holidays add 2026-10-16 "Office closed"
+  sultengutt holidays add 12-24 "Christmas Eve"`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.IsFreshInstall() {
+				fmt.Println(errorStyle.Render("Sultengutt has not been installed yet, please run `sultengutt install` first"))
+				return nil
+			}
+			name := ""
+			if len(args) == 2 {
+				name = args[1]
+			}
+			if err := runHolidaysAdd(cfg, args[0], name); err != nil {
+				return err
+			}
+			return cm.SaveWithHistory(cfg)
+		},
+	}
+
+	holidaysRmCmd := &cobra.Command{
+		Use:   "rm DATE",
+		Short: "Remove a custom holiday",
+		Long:  "Remove a custom holiday by its date as shown in the config.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.IsFreshInstall() {
+				fmt.Println(errorStyle.Render("Sultengutt has not been installed yet, please run `sultengutt install` first"))
+				return nil
+			}
+			if err := runHolidaysRemove(cfg, args[0]); err != nil {
+				return err
+			}
+			return cm.SaveWithHistory(cfg)
+		},
+	}
+
+	holidaysRegionsCmd := &cobra.Command{
+		Use:   "regions CODE... | none",
+		Short: "Choose which public holiday calendars to use",
+		Long:  "Choose which public holiday calendars to use. Supported regions: " + strings.Join(holidays.Regions(), ", "),
+		Example: `  sultengutt holidays regions NO
+  sultengutt holidays regions none`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.IsFreshInstall() {
+				fmt.Println(errorStyle.Render("Sultengutt has not been installed yet, please run `sultengutt install` first"))
+				return nil
+			}
+			regions := []string{}
+			if !(len(args) == 1 && strings.EqualFold(args[0], "none")) {
+				for _, region := range args {
+					if !holidays.IsSupported(region) {
+						return fmt.Errorf("unsupported holiday region: %s (supported: %s)", region, strings.Join(holidays.Regions(), ", "))
+					}
+					regions = append(regions, strings.ToUpper(region))
+				}
+			}
+			cfg.Holidays.Regions = regions
+			if err := cm.SaveWithHistory(cfg); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+			fmt.Println(infoStyle.Render("Holiday regions updated"))
+			return nil
+		},
+	}
+	holidaysCmd.AddCommand(holidaysListCmd, holidaysAddCmd, holidaysRmCmd, holidaysRegionsCmd)
+
+	notifiersCmd := &cobra.Command{
+		Use:   "notifiers",
+		Short: "Choose how reminders are delivered",
+		Long: "Reminders are delivered by the first notifier that works, falling back to the next one when a notifier fails.\n\n" +
+			"Available notifiers: " + strings.Join(config.Notifiers, ", "),
+		Example: `  sultengutt notifiers
+  sultengutt notifiers order popup notification terminal
+  sultengutt notifiers command 'notify-send "$SULTENGUTT_TITLE" "$SULTENGUTT_URL"'
+  sultengutt notifiers webhook https://hooks.example.com/dinner`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runNotifiersList(*cfg)
+		},
+	}
+
+	notifiersOrderCmd := &cobra.Command{
+		Use:   "order NAME... | default",
+		Short: "Set the order notifiers are tried in",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := runNotifiersOrder(cfg, args); err != nil {
+				return err
+			}
+			return cm.SaveWithHistory(cfg)
+		},
+	}
+
+	notifiersCommandCmd := &cobra.Command{
+		Use:   "command CMD",
+		Short: "Set the shell command run by the command notifier",
+		Long:  "Set the shell command run by the command notifier. The reminder is passed in SULTENGUTT_TITLE, SULTENGUTT_MESSAGE, SULTENGUTT_MANTRA and SULTENGUTT_URL.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cfg.SetNotifierCommand(args[0]); err != nil {
+				return err
+			}
+			return cm.SaveWithHistory(cfg)
+		},
+	}
+
+	notifiersWebhookCmd := &cobra.Command{
+		Use:   "webhook URL",
+		Short: "Set the URL the webhook notifier posts the reminder to",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cfg.SetNotifierWebhook(args[0]); err != nil {
+				return err
+			}
+			return cm.SaveWithHistory(cfg)
+		},
+	}
+	notifiersCmd.AddCommand(notifiersOrderCmd, notifiersCommandCmd, notifiersWebhookCmd)
+
+	snoozeCmd := &cobra.Command{
+		Use:   "snooze [duration]",
+		Short: "Show the reminder again after a while",
+		Long:  "Snooze the reminder, showing it again after the given duration (default: the first snooze option, 10 minutes unless configured).",
+		Example: `  sultengutt snooze
+  sultengutt snooze 30m
+  sultengutt snooze 1 hour`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			d, err := parseSnoozeDuration(*cfg, args)
+			if err != nil {
+				return err
+			}
+			snoozer, err := scheduler.NewSnoozer(cm.ConfigDir())
+			if err != nil {
+				return err
+			}
+			until, err := runSnooze(cfg, snoozer, d, time.Now())
+			if err != nil {
+				return err
+			}
+			if err := cm.Save(cfg); err != nil {
+				return err
+			}
+			fmt.Println(successStyle.Render("✓ Snoozed until " + until.Format(snoozeTimeFormat)))
+			return nil
+		},
+	}
+
+	unsnoozeCmd := &cobra.Command{
+		Use:   "unsnooze",
+		Short: "Cancel a snoozed reminder",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			snoozer, err := scheduler.NewSnoozer(cm.ConfigDir())
+			if err != nil {
+				return err
+			}
+			if err := runUnsnooze(cfg, snoozer, time.Now()); err != nil {
+				return err
+			}
+			return cm.Save(cfg)
+		},
+	}
+
+	orderCmd := &cobra.Command{
+		Use:   "order [vendor]",
+		Short: "Open the order page and record an order",
+		Long: `Opens the order page of a vendor in the configured browser and records the order
+in the reminder log. Without a vendor the one last ordered from is used. A snoozed
+reminder is cancelled, as there is nothing left to remind of.`,
+		Example: `  sultengutt order
+  sultengutt order foodora`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.IsFreshInstall() {
+				fmt.Println(errorStyle.Render("Sultengutt has not been installed yet, please run `sultengutt install` first"))
+				return nil
+			}
+			name := ""
+			if len(args) == 1 {
+				name = args[0]
+			}
+			now := time.Now()
+			if err := runOrder(cm, cfg, name, opener.New(cfg.Browser).Open, now); err != nil {
+				return err
+			}
+			if cfg.IsSnoozed(now) {
+				snoozer, err := scheduler.NewSnoozer(cm.ConfigDir())
+				if err != nil {
+					return err
+				}
+				if err := runUnsnooze(cfg, snoozer, now); err != nil {
+					return err
+				}
+			}
+			return cm.Save(cfg)
+		},
+	}
+
+	vendorsCmd := &cobra.Command{
+		Use:   "vendors",
+		Short: "Manage the places to order from",
+		Long: "The reminder lets you pick the vendor to order from when there are several,\n" +
+			"preselecting the one last ordered from.\n\n" +
+			"Vendor URLs may contain placeholders expanded when the reminder fires: {date} (2026-10-16),\n" +
+			"{weekday} (friday), {iso_week} (42), {user} and {env:NAME} for an environment variable.",
+		Example: `  sultengutt vendors
+  sultengutt vendors add Foodora https://www.foodora.no --icon 🛵
+  sultengutt vendors add Canteen 'https://canteen.example.com/menu?date={date}'
+  sultengutt vendors rm Foodora`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runVendorsList(*cfg)
+		},
+	}
+
+	vendorsAddCmd := &cobra.Command{
+		Use:   "add NAME URL",
+		Short: "Add a vendor",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			icon, _ := cmd.Flags().GetString("icon")
+			if err := runVendorsAdd(cfg, config.Vendor{Name: args[0], URL: args[1], Icon: icon}); err != nil {
+				return err
+			}
+			return cm.SaveWithHistory(cfg)
+		},
+	}
+	vendorsAddCmd.Flags().String("icon", "", "emoji shown before the vendor's name")
+
+	vendorsRmCmd := &cobra.Command{
+		Use:   "rm NAME",
+		Short: "Remove a vendor",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := runVendorsRemove(cfg, args[0]); err != nil {
+				return err
+			}
+			return cm.SaveWithHistory(cfg)
+		},
+	}
+	vendorsCmd.AddCommand(vendorsAddCmd, vendorsRmCmd)
+
+	calendarCmd := &cobra.Command{
+		Use:   "calendar",
+		Short: "Keep the reminder out of meetings",
+		Long: "With a calendar, a reminder falling into a meeting is shown in the next free gap\n" +
+			"instead, before the order cutoff. When there is no gap in time it is shown right away.\n\n" +
+			"The calendar is a local .ics file, e.g. one your calendar app exports or syncs. It is\n" +
+			"read every time the reminder fires. Events shown as free and all-day events don't count.",
+		Example: `  sultengutt calendar
+  sultengutt calendar set ~/Calendars/work.ics --gap 10
+  sultengutt calendar off`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCalendarShow(*cfg, time.Now())
+		},
+	}
+
+	calendarSetCmd := &cobra.Command{
+		Use:   "set PATH",
+		Short: "Use a calendar file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			gap, _ := cmd.Flags().GetInt("gap")
+			if err := runCalendarSet(cfg, args[0], gap); err != nil {
+				return err
+			}
+			return cm.SaveWithHistory(cfg)
+		},
+	}
+	calendarSetCmd.Flags().Int("gap", 0, fmt.Sprintf("minutes of free time needed to show the reminder (default %d)", int(config.DefaultCalendarGap.Minutes())))
+
+	calendarOffCmd := &cobra.Command{
+		Use:   "off",
+		Short: "Stop using a calendar",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runCalendarOff(cfg)
+			return cm.SaveWithHistory(cfg)
+		},
+	}
+	calendarCmd.AddCommand(calendarSetCmd, calendarOffCmd)
+
+	cutoffCmd := &cobra.Command{
+		Use:   "cutoff HH:MM|off",
+		Short: "Set the latest time worth ordering at",
+		Long: "Reminders waiting for a meeting to end are shown before the order cutoff at the latest,\n" +
+			"and reminders nobody answered are not shown again after it.\n" +
+			fmt.Sprintf("Without a cutoff they wait up to %d hours.", int(config.DefaultDeferLimit.Hours())),
+		Example: `  sultengutt cutoff 15:30
+  sultengutt cutoff off`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := runCutoff(cfg, args[0]); err != nil {
+				return err
+			}
+			return cm.SaveWithHistory(cfg)
+		},
+	}
+
+	refireCmd := &cobra.Command{
+		Use:   "refire [delay|off]",
+		Short: "Show reminders nobody answered again",
+		Long: "When the reminder popup closes by itself because nobody answered, the reminder can be\n" +
+			"shown again after a delay, up to a number of times in total and never after the order\n" +
+			"cutoff (see 'sultengutt cutoff'). The popup tells which showing it is, e.g. reminder 2 of 3.",
+		Example: `  sultengutt refire
+  sultengutt refire 15m
+  sultengutt refire 20 minutes --times 4
+  sultengutt refire off`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch {
+			case len(args) == 0:
+				runRefireShow(*cfg)
+				return nil
+			case len(args) == 1 && args[0] == "off":
+				runRefireOff(cfg)
+			default:
+				times, _ := cmd.Flags().GetInt("times")
+				if err := runRefireSet(cfg, args, times); err != nil {
+					return err
+				}
+			}
+			return cm.SaveWithHistory(cfg)
+		},
+	}
+	refireCmd.Flags().Int("times", 0, fmt.Sprintf("times the reminder is shown in total (default %d)", config.DefaultRefireAttempts))
+
+	mantraCmd := &cobra.Command{
+		Use:   "mantra",
+		Short: "Manage the mantras shown in the reminder",
+		Long: "The reminder shows a mantra picked from the built-in ones and your packs: JSON lists\n" +
+			"of strings in the mantras directory of the config dir (~/.sultengutt/mantras).\n" +
+			"Mantras you add go to the custom pack.\n\n" +
+			"Every day has one mantra, shown in all of its reminders, and none repeats before\n" +
+			"all of them have been shown.",
+		Example: `  sultengutt mantra list
+  sultengutt mantra add "Feed the team, feed the dream"
+  sultengutt mantra rm 3
+  sultengutt mantra import stoic.json
+  sultengutt mantra builtin off
+  sultengutt mantra today`,
+	}
+
+	mantraListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the mantras",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMantraList(*cfg, cfg.MantraPackDir())
+		},
+	}
+
+	mantraAddCmd := &cobra.Command{
+		Use:   "add TEXT",
+		Short: "Add a mantra to your custom pack",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := runMantraAdd(cfg, cfg.MantraPackDir(), args[0]); err != nil {
+				return err
+			}
+			return cm.SaveWithHistory(cfg)
+		},
+	}
+
+	mantraRmCmd := &cobra.Command{
+		Use:   "rm NUMBER|TEXT",
+		Short: "Remove a mantra",
+		Long:  "Remove a mantra by its number in 'sultengutt mantra list' or its text.\nBuilt-in mantras are hidden, 'sultengutt mantra add' brings them back.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := runMantraRemove(cfg, cfg.MantraPackDir(), args[0]); err != nil {
+				return err
+			}
+			return cm.SaveWithHistory(cfg)
+		},
+	}
+
+	mantraImportCmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "Import a pack of mantras from a JSON file",
+		Long:  "Import a JSON list of mantras as a pack, replacing a pack of the same name.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, _ := cmd.Flags().GetString("name")
+			return runMantraImport(cfg.MantraPackDir(), args[0], name)
+		},
+	}
+	mantraImportCmd.Flags().String("name", "", "name of the pack (default: the file name)")
+
+	mantraBuiltinCmd := &cobra.Command{
+		Use:   "builtin on|off",
+		Short: "Show the built-in mantras or only your packs",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := runMantraBuiltin(cfg, args[0]); err != nil {
+				return err
+			}
+			return cm.SaveWithHistory(cfg)
+		},
+	}
+
+	mantraTodayCmd := &cobra.Command{
+		Use:   "today",
+		Short: "Show today's mantra",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMantraToday(cm, cfg, time.Now())
+		},
+	}
+	mantraCmd.AddCommand(mantraListCmd, mantraAddCmd, mantraRmCmd, mantraImportCmd, mantraBuiltinCmd, mantraTodayCmd)
+
+	previewCmd := &cobra.Command{
+		Use:   "preview",
+		Short: "Show the reminder popup now",
+		Long: `Shows the reminder popup right away with the current config, also while paused,
+to try out texts and themes. Nothing is recorded in the reminder log and a snooze
+is not scheduled.
+
+With --screenshot the popup is rendered to a PNG file instead of being shown,
+using Fyne's software renderer, so it works without a display or GPU.`,
+		Example: `  sultengutt preview
+  sultengutt preview --screenshot popup.png`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.New(os.Stderr, "", 0)
+			now := time.Now()
+			mantra, err := todaysMantra(cm, cfg, now)
+			if err != nil {
+				logger.Printf("failed to pick the mantra: %v", err)
+			}
+			m := reminderModel(*cfg, mantra, now, logger)
+			if path, _ := cmd.Flags().GetString("screenshot"); path != "" {
+				img, err := popup.Screenshot(m)
+				if err != nil {
+					return err
+				}
+				if err := writeScreenshot(path, img); err != nil {
+					return err
+				}
+				fmt.Println(successStyle.Render("✓ Saved the popup to " + path))
+				return nil
+			}
+			popup.SetOpener(opener.New(cfg.Browser))
+			result, err := popup.ShowPopup(m)
+			if err != nil {
+				return err
+			}
+			fmt.Println(infoStyle.Render("Preview closed: " + formatPreviewResult(result)))
+			return nil
+		},
+	}
+	previewCmd.Flags().String("screenshot", "", "render the popup to this PNG file instead of showing it")
+
+	rootCmd.AddCommand(installCmd, executeCmd, pauseCmd, resumeCmd, statusCmd, uninstallCmd, configCmd, skipCmd, holidaysCmd, notifiersCmd, snoozeCmd, unsnoozeCmd, orderCmd, vendorsCmd, calendarCmd, cutoffCmd, refireCmd, mantraCmd, previewCmd)
 
 	rootCmd.SetErrPrefix(errorStyle.Render("Error:"))
 
@@ -163,19 +1049,39 @@ func main() {
 func runInstall(cfg *config.Config, cm *config.ConfigManager) error {
 
 	installed := cfg.IsFreshInstall()
-	opts, err := installer.RunInstaller(installed, cfg.InstallOptions)
+	prev := cfg.InstallOptions
+	if vendors := cfg.OrderVendors(); prev.SiteLink == "" && len(vendors) > 0 {
+		// the installer asks for the first vendor's order page
+		prev.SiteLink = vendors[0].URL
+	}
+	opts, err := installer.RunInstaller(installed, prev)
 	if err != nil {
 		return fmt.Errorf("installation cancelled or failed: %w", err)
 	}
 
 	cfg.InstallOptions = opts
-	err = cm.Save(cfg)
+	if err := cfg.CheckOrderCutoff(); err != nil {
+		return fmt.Errorf("%w, change it with 'sultengutt cutoff' or turn it off with 'sultengutt cutoff off' first", err)
+	}
+	cfg.SetOrderURL(opts.SiteLink)
+	err = cm.SaveWithHistory(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
-	sch := scheduler.NewScheduler(opts, cm.ConfigDir())
-	if installed {
+	if err := syncTask(opts, cm.ConfigDir(), installed); err != nil {
+		return err
+	}
+
+	fmt.Println(successStyle.Render("✓ Sultengutt is set up! Happy dining!"))
+	fmt.Println(infoStyle.Render("Config saved to: " + cfg.Path() + "\n\n"))
+	return nil
+}
+
+// syncTask (re-)registers the OS scheduled task so it matches the given options
+func syncTask(opts config.InstallOptions, configDir string, replace bool) error {
+	sch := scheduler.NewScheduler(opts, configDir)
+	if replace {
 		if err := sch.UnregisterTask(); err != nil {
 			return fmt.Errorf("failed to unregister old task: %w", err)
 		}
@@ -183,9 +1089,6 @@ func runInstall(cfg *config.Config, cm *config.ConfigManager) error {
 	if err := sch.RegisterTask(); err != nil {
 		return fmt.Errorf("failed to register task: %w", err)
 	}
-
-	fmt.Println(successStyle.Render("✓ Sultengutt is set up! Happy dining!"))
-	fmt.Println(infoStyle.Render("Config saved to: " + cfg.Path() + "\n\n"))
 	return nil
 }
 
@@ -197,15 +1100,24 @@ func runPause(args []string, cfg *config.Config) error {
 		return nil
 	}
 
-	duration, err := utils.ParseDuration(args)
-	if err != nil {
-		return fmt.Errorf("error parsing duration: %v", err)
-	}
+	var pauseUntil int64
+	if strings.EqualFold(args[0], "until") {
+		until, err := utils.ParseUntil(args[1:], cfg.InstallOptions.Hour, time.Now())
+		if err != nil {
+			return fmt.Errorf("error parsing date: %v", err)
+		}
+		pauseUntil = until.Unix()
+	} else {
+		duration, err := utils.ParseDuration(args)
+		if err != nil {
+			return fmt.Errorf("error parsing duration: %v", err)
+		}
 
-	scheduledHour := cfg.InstallOptions.Hour
-	pauseUntil, err := utils.CalculatePauseUntil(duration, scheduledHour)
-	if err != nil {
-		return fmt.Errorf("error calculating pause time: %v", err)
+		scheduledHour := cfg.InstallOptions.Hour
+		pauseUntil, err = utils.CalculatePauseUntil(duration, scheduledHour)
+		if err != nil {
+			return fmt.Errorf("error calculating pause time: %v", err)
+		}
 	}
 
 	cfg.PausedUntil = pauseUntil
@@ -229,13 +1141,63 @@ func runStatus(cfg config.Config) {
 	fmt.Println()
 	fmt.Println("Status:")
 	fmt.Println("  Config path: " + cfg.Path())
+	now := time.Now()
 	if cfg.PausedUntil > 0 {
 		fmt.Println("  Paused: paused until " + time.Unix(cfg.PausedUntil, 0).Format("Monday, January 2, 2006 15:04"))
 		fmt.Println("  tip: use 'sultengutt resume' to unpause early")
+	} else if cfg.PausedUntil == 0 {
+		fmt.Println("  Paused: paused indefinitely")
+		fmt.Println("  tip: use 'sultengutt resume' to unpause")
+	} else if w, ok := cfg.ActivePauseWindow(now); ok {
+		fmt.Println("  Paused: planned pause until " + time.Unix(w.To, 0).Format("Monday, January 2, 2006 15:04"))
+		if w.Reason != "" {
+			fmt.Println("  Reason: " + w.Reason)
+		}
+		fmt.Printf("  tip: use 'sultengutt pause rm %d' to cancel it\n", w.ID)
 	} else {
 		fmt.Println("  Paused: not paused (active)")
 	}
 
+	if cfg.IsPaused() && cfg.PauseReason != "" {
+		fmt.Println("  Reason: " + cfg.PauseReason)
+	}
+
+	if cfg.IsSnoozed(now) {
+		fmt.Println("  Snoozed: reminder again at " + time.Unix(cfg.State.SnoozedUntil, 0).Format(snoozeTimeFormat))
+		fmt.Println("  tip: use 'sultengutt unsnooze' to cancel it")
+	}
+
+	if h, ok := nextSkippedHoliday(cfg, now); ok {
+		fmt.Println("  Next holiday: " + h.Name + ", " + h.Date.Format(holidayDateFormat) + " (reminder skipped)")
+	}
+
+	var skipped []string
+	for _, date := range cfg.Skips {
+		if date >= now.Format("2006-01-02") {
+			skipped = append(skipped, date)
+		}
+	}
+	if len(skipped) > 0 {
+		fmt.Println("  Skipped reminders: " + strings.Join(skipped, ", "))
+	}
+	if err := cfg.CheckOrderCutoff(); err != nil {
+		fmt.Println("  Order cutoff: ignored, " + err.Error())
+	}
+
+	var planned []config.PauseWindow
+	for _, w := range cfg.PauseWindows {
+		if w.To > now.Unix() {
+			planned = append(planned, w)
+		}
+	}
+	if len(planned) > 0 {
+		fmt.Println()
+		fmt.Println("Planned pauses:")
+		for _, w := range planned {
+			fmt.Println("  " + formatPauseWindow(w))
+		}
+	}
+
 }
 
 func runUninstall(cfg *config.Config, cm *config.ConfigManager, skipConfirm bool) error {
@@ -265,6 +1227,11 @@ func runUninstall(cfg *config.Config, cm *config.ConfigManager, skipConfirm bool
 	if err := sch.UnregisterTask(); err != nil {
 		return fmt.Errorf("failed to unregister scheduled task: %w", err)
 	}
+	if snoozer, err := scheduler.NewSnoozer(cm.ConfigDir()); err == nil {
+		if err := snoozer.CancelSnooze(); err != nil {
+			return fmt.Errorf("failed to cancel snooze: %w", err)
+		}
+	}
 	fmt.Println(successStyle.Render("Removed scheduled tasks"))
 
 	if err := cm.Clean(); err != nil {
@@ -2,12 +2,24 @@ package main
 
 import (
 	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sultengutt/assets"
 	"sultengutt/internal/config"
+	"sultengutt/internal/notify"
+	"sultengutt/internal/popup/model"
 	"testing"
 	"time"
+
+	"github.com/spf13/cobra"
 )
 
 func TestRunPause(t *testing.T) {
@@ -36,6 +48,26 @@ func TestRunPause(t *testing.T) {
 			args:        []string{"1", "day"},
 			expectError: false,
 		},
+		{
+			name:        "pause until weekday",
+			args:        []string{"until", "monday"},
+			expectError: false,
+		},
+		{
+			name:        "pause until date",
+			args:        []string{"until", time.Now().AddDate(0, 0, 10).Format("2006-01-02")},
+			expectError: false,
+		},
+		{
+			name:        "pause until past date",
+			args:        []string{"until", "2020-01-01"},
+			expectError: true,
+		},
+		{
+			name:        "pause until without date",
+			args:        []string{"until"},
+			expectError: true,
+		},
 		{
 			name:        "invalid duration",
 			args:        []string{"invalid", "duration"},
@@ -161,6 +193,721 @@ func TestRunStatusPaused(t *testing.T) {
 	}
 }
 
+func TestRunPauseAdd(t *testing.T) {
+	now := time.Date(2026, 10, 14, 10, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name         string
+		from         string
+		to           string
+		expectedFrom time.Time
+		expectedTo   time.Time
+		expectError  bool
+	}{
+		{
+			name:         "whole days",
+			from:         "2026-12-21",
+			to:           "2027-01-03",
+			expectedFrom: time.Date(2026, 12, 21, 0, 0, 0, 0, time.Local),
+			expectedTo:   time.Date(2027, 1, 4, 0, 0, 0, 0, time.Local),
+		},
+		{
+			name:         "with times",
+			from:         "2026-12-21 12:00",
+			to:           "2026-12-22 08:00",
+			expectedFrom: time.Date(2026, 12, 21, 12, 0, 0, 0, time.Local),
+			expectedTo:   time.Date(2026, 12, 22, 8, 0, 0, 0, time.Local),
+		},
+		{
+			name:         "single day",
+			from:         "2026-10-14",
+			to:           "2026-10-14",
+			expectedFrom: time.Date(2026, 10, 14, 0, 0, 0, 0, time.Local),
+			expectedTo:   time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local),
+		},
+		{name: "ends in the past", from: "2026-01-01", to: "2026-01-07", expectError: true},
+		{name: "end before start", from: "2026-12-27", to: "2026-12-21", expectError: true},
+		{name: "invalid from", from: "christmas", to: "2026-12-27", expectError: true},
+		{name: "invalid to", from: "2026-12-21", to: "", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{PausedUntil: -1}
+
+			_, err := runPauseAdd(cfg, tt.from, tt.to, "vacation", now)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(cfg.PauseWindows) != 1 {
+				t.Fatalf("Expected 1 planned pause, got %d", len(cfg.PauseWindows))
+			}
+			w := cfg.PauseWindows[0]
+			if w.From != tt.expectedFrom.Unix() || w.To != tt.expectedTo.Unix() {
+				t.Errorf("Expected %v → %v, got %v → %v", tt.expectedFrom, tt.expectedTo, time.Unix(w.From, 0), time.Unix(w.To, 0))
+			}
+			if w.Reason != "vacation" {
+				t.Errorf("Expected reason 'vacation', got '%s'", w.Reason)
+			}
+		})
+	}
+}
+
+func TestRunStatusPlannedPause(t *testing.T) {
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	now := time.Now()
+	cfg := config.Config{
+		InstallOptions: config.InstallOptions{
+			Days:     []string{"Monday"},
+			Hour:     "09:00",
+			SiteLink: "https://test.com",
+		},
+		PausedUntil: -1,
+		PauseWindows: []config.PauseWindow{
+			{ID: 1, From: now.Add(-48 * time.Hour).Unix(), To: now.Add(-24 * time.Hour).Unix(), Reason: "expired"},
+			{ID: 2, From: now.Add(-time.Hour).Unix(), To: now.Add(24 * time.Hour).Unix(), Reason: "vacation"},
+			{ID: 3, From: now.Add(30 * 24 * time.Hour).Unix(), To: now.Add(37 * 24 * time.Hour).Unix(), Reason: "ski week"},
+		},
+	}
+
+	runStatus(cfg)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	output := buf.String()
+
+	for _, content := range []string{"planned pause until", "Planned pauses:", "(vacation)", "(ski week)"} {
+		if !strings.Contains(output, content) {
+			t.Errorf("Expected output to contain '%s', but it doesn't.\nOutput: %s", content, output)
+		}
+	}
+	if strings.Contains(output, "expired") {
+		t.Errorf("Expected expired pause to be hidden.\nOutput: %s", output)
+	}
+}
+
+func TestNextSkippedHoliday(t *testing.T) {
+	cfg := config.Config{
+		InstallOptions: config.InstallOptions{
+			Days:     []string{"Thursday"},
+			Hour:     "16:00",
+			SiteLink: "https://example.com",
+		},
+		PausedUntil: -1,
+		Holidays:    config.HolidaySettings{Regions: []string{"NO"}},
+	}
+
+	// Easter 2026 is April 5, so the next Thursday holiday is Ascension Day
+	h, ok := nextSkippedHoliday(cfg, time.Date(2026, 4, 10, 12, 0, 0, 0, time.Local))
+	if !ok {
+		t.Fatal("Expected a holiday to be found")
+	}
+	if h.Name != "Ascension Day" {
+		t.Errorf("Expected Ascension Day, got %s", h.Name)
+	}
+
+	cfg.Holidays.Regions = []string{}
+	if h, ok := nextSkippedHoliday(cfg, time.Date(2026, 4, 10, 12, 0, 0, 0, time.Local)); ok {
+		t.Errorf("Expected no holiday without regions, got %s", h.Name)
+	}
+}
+
+type fakeSnoozer struct {
+	at        time.Time
+	cancelled bool
+	err       error
+}
+
+func (f *fakeSnoozer) Snooze(at time.Time) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.at = at
+	return nil
+}
+
+func (f *fakeSnoozer) CancelSnooze() error {
+	f.cancelled = true
+	return nil
+}
+
+func TestRunSnooze(t *testing.T) {
+	now := time.Date(2026, 10, 19, 16, 0, 30, 0, time.Local)
+
+	t.Run("rounds up to whole minutes", func(t *testing.T) {
+		cfg := &config.Config{PausedUntil: -1}
+		snoozer := &fakeSnoozer{}
+
+		until, err := runSnooze(cfg, snoozer, 10*time.Minute, now)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := time.Date(2026, 10, 19, 16, 11, 0, 0, time.Local)
+		if !until.Equal(expected) || !snoozer.at.Equal(expected) {
+			t.Errorf("Expected snooze until %v, got %v (scheduled %v)", expected, until, snoozer.at)
+		}
+		if cfg.State.SnoozedUntil != expected.Unix() {
+			t.Errorf("Expected the snooze to be saved in the config, got %d", cfg.State.SnoozedUntil)
+		}
+	})
+
+	t.Run("scheduling fails", func(t *testing.T) {
+		cfg := &config.Config{PausedUntil: -1}
+		if _, err := runSnooze(cfg, &fakeSnoozer{err: os.ErrPermission}, 10*time.Minute, now); err == nil {
+			t.Error("Expected an error")
+		}
+		if cfg.State.SnoozedUntil != 0 {
+			t.Error("Expected no snooze in the config when scheduling fails")
+		}
+	})
+}
+
+func TestRunUnsnooze(t *testing.T) {
+	now := time.Date(2026, 10, 19, 16, 0, 0, 0, time.Local)
+	cfg := &config.Config{PausedUntil: -1}
+	cfg.SnoozeUntil(now.Add(30 * time.Minute))
+	snoozer := &fakeSnoozer{}
+
+	if err := runUnsnooze(cfg, snoozer, now); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !snoozer.cancelled || cfg.IsSnoozed(now) {
+		t.Error("Expected the snooze to be cancelled")
+	}
+}
+
+func TestSnoozeDue(t *testing.T) {
+	now := time.Date(2026, 10, 19, 16, 30, 0, 0, time.Local)
+	at := now.Unix()
+
+	tests := []struct {
+		name     string
+		snoozed  int64
+		at       int64
+		expected bool
+	}{
+		{"not snoozed", 0, 0, false},
+		{"cancelled timer", 0, at, false},
+		{"timer for this snooze", at, at, true},
+		{"timer for an earlier snooze", at + 600, at, false},
+		{"scheduled run", at, 0, true},
+		{"scheduled run slightly early", at + 30, 0, true},
+		{"scheduled run for a later snooze", at + 600, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Config{State: config.State{SnoozedUntil: tt.snoozed}}
+			if got := snoozeDue(cfg, tt.at, now); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseSnoozeDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.Config
+		args     []string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"default", config.Config{}, nil, 10 * time.Minute, false},
+		{"configured default", config.Config{SnoozeMinutes: []int{45, 90}}, nil, 45 * time.Minute, false},
+		{"snoozing disabled", config.Config{SnoozeMinutes: []int{}}, nil, 0, true},
+		{"minutes", config.Config{}, []string{"30m"}, 30 * time.Minute, false},
+		{"words", config.Config{}, []string{"1", "hour"}, time.Hour, false},
+		{"too long", config.Config{}, []string{"2", "days"}, 0, true},
+		{"invalid", config.Config{}, []string{"soon"}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSnoozeDuration(tt.cfg, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSnoozeDuration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRunSkip(t *testing.T) {
+	// Wednesday, October 14 2026 at 10:00
+	now := time.Date(2026, 10, 14, 10, 0, 0, 0, time.Local)
+	newConfig := func() *config.Config {
+		return &config.Config{
+			InstallOptions: config.InstallOptions{
+				Days:     []string{"Wednesday", "Friday"},
+				Hour:     "16:00",
+				SiteLink: "https://example.com",
+			},
+			PausedUntil: -1,
+		}
+	}
+
+	t.Run("skip next", func(t *testing.T) {
+		cfg := newConfig()
+		if err := runSkipNext(cfg, 2, now); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if strings.Join(cfg.Skips, ",") != "2026-10-14,2026-10-16" {
+			t.Errorf("Expected today and friday to be skipped, got %v", cfg.Skips)
+		}
+
+		// the next one after those already skipped
+		if err := runSkipNext(cfg, 1, now); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(cfg.Skips) != 3 || cfg.Skips[2] != "2026-10-21" {
+			t.Errorf("Expected the following wednesday to be skipped, got %v", cfg.Skips)
+		}
+
+		if err := runSkipNext(cfg, 0, now); err == nil {
+			t.Error("Expected error for zero reminders, but got none")
+		}
+	})
+
+	t.Run("skip date", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			date        string
+			expectError bool
+		}{
+			{"scheduled day", "2026-10-16", false},
+			{"later today", "2026-10-14", false},
+			{"not a scheduled day", "2026-10-15", true},
+			{"in the past", "2026-10-09", true},
+			{"with time", "2026-10-16 16:00", true},
+			{"invalid", "friday", true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				cfg := newConfig()
+				err := runSkipDate(cfg, tt.date, now)
+
+				if tt.expectError {
+					if err == nil {
+						t.Error("Expected error, but got none")
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if len(cfg.Skips) != 1 || cfg.Skips[0] != tt.date {
+					t.Errorf("Expected %s to be skipped, got %v", tt.date, cfg.Skips)
+				}
+			})
+		}
+
+		// today's reminder already fired
+		cfg := newConfig()
+		if err := runSkipDate(cfg, "2026-10-14", now.Add(7*time.Hour)); err == nil {
+			t.Error("Expected error for a reminder that already fired, but got none")
+		}
+	})
+}
+
+func TestRunStatusPauseReason(t *testing.T) {
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	cfg := config.Config{
+		InstallOptions: config.InstallOptions{
+			Days:     []string{"Monday"},
+			Hour:     "09:00",
+			SiteLink: "https://test.com",
+		},
+		PausedUntil: 0,
+		PauseReason: "office closed",
+	}
+
+	runStatus(cfg)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	output := buf.String()
+
+	for _, content := range []string{"paused indefinitely", "Reason: office closed"} {
+		if !strings.Contains(output, content) {
+			t.Errorf("Expected output to contain '%s', but it doesn't.\nOutput: %s", content, output)
+		}
+	}
+}
+
+func TestFormatPauseEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    config.PauseEvent
+		contains []string
+	}{
+		{
+			name:     "pause with reason",
+			event:    config.PauseEvent{Timestamp: 1, Action: config.PauseActionPause, User: "tobias", Reason: "vacation", Until: time.Date(2026, 12, 27, 16, 0, 0, 0, time.Local).Unix()},
+			contains: []string{"tobias", "paused until Sun Dec 27 2026 16:00", "(vacation)"},
+		},
+		{
+			name:     "indefinite pause",
+			event:    config.PauseEvent{Timestamp: 1, Action: config.PauseActionPause, User: "tobias"},
+			contains: []string{"paused indefinitely"},
+		},
+		{
+			name:     "auto resume",
+			event:    config.PauseEvent{Timestamp: 1, Action: config.PauseActionAutoResume},
+			contains: []string{"unknown", "resumed automatically"},
+		},
+		{
+			name: "planned pause",
+			event: config.PauseEvent{Timestamp: 1, Action: config.PauseActionPlan, User: "tobias", Reason: "christmas", Window: 2,
+				From: time.Date(2026, 12, 21, 0, 0, 0, 0, time.Local).Unix(), Until: time.Date(2027, 1, 4, 0, 0, 0, 0, time.Local).Unix()},
+			contains: []string{"planned pause 2 from Mon Dec 21 2026 00:00 until Mon Jan 4 2027 00:00", "(christmas)"},
+		},
+		{
+			name:     "removed planned pause",
+			event:    config.PauseEvent{Timestamp: 1, Action: config.PauseActionUnplan, Window: 2, From: 1, Until: 2},
+			contains: []string{"removed planned pause 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := formatPauseEvent(tt.event)
+			for _, content := range tt.contains {
+				if !strings.Contains(line, content) {
+					t.Errorf("Expected '%s' to contain '%s'", line, content)
+				}
+			}
+		})
+	}
+}
+
+func TestRunNotifiersOrder(t *testing.T) {
+	cfg := &config.Config{PausedUntil: -1}
+
+	if err := runNotifiersOrder(cfg, []string{"terminal", "popup"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if order := cfg.NotifierOrder(); len(order) != 2 || order[0] != "terminal" {
+		t.Errorf("Expected [terminal popup], got %v", order)
+	}
+
+	if err := runNotifiersOrder(cfg, []string{"webhook"}); err == nil {
+		t.Error("Expected an error for a webhook without a URL")
+	}
+
+	if err := runNotifiersOrder(cfg, []string{"default"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Notifiers.Order != nil {
+		t.Errorf("Expected the default order, got %v", cfg.Notifiers.Order)
+	}
+}
+
+func TestRecordOutcome(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cm, err := config.NewConfigManager()
+	if err != nil {
+		t.Fatalf("Failed to create config manager: %v", err)
+	}
+	if err := os.MkdirAll(cm.ConfigDir(), 0755); err != nil {
+		t.Fatalf("Failed to create config directory: %v", err)
+	}
+	now := time.Date(2026, 10, 19, 16, 0, 0, 0, time.Local)
+	outcome := notify.Outcome{
+		Notifier: "popup",
+		Result:   model.Result{Action: model.ActionSnooze, SnoozeFor: 30 * time.Minute},
+	}
+
+	if err := recordOutcome(cm, outcome, now); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	last, ok, err := cm.LastReminder()
+	if err != nil || !ok {
+		t.Fatalf("Expected a recorded outcome, got ok=%v err=%v", ok, err)
+	}
+	if got := formatReminderOutcome(last); got != "snoozed for 30 min, Mon Oct 19 2026 16:00 (popup)" {
+		t.Errorf("Unexpected formatted outcome '%s'", got)
+	}
+}
+
+func TestRunOrder(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cm, err := config.NewConfigManager()
+	if err != nil {
+		t.Fatalf("Failed to create config manager: %v", err)
+	}
+	if err := os.MkdirAll(cm.ConfigDir(), 0755); err != nil {
+		t.Fatalf("Failed to create config directory: %v", err)
+	}
+	cfg := &config.Config{InstallOptions: config.InstallOptions{SiteLink: "https://example.com"}}
+	now := time.Date(2026, 10, 19, 16, 0, 0, 0, time.Local)
+
+	// a failing browser records nothing
+	err = runOrder(cm, cfg, "", func(string) error { return errors.New("no browser") }, now)
+	if err == nil {
+		t.Fatal("Expected the browser error")
+	}
+	if _, ok, _ := cm.LastReminder(); ok {
+		t.Error("Expected no order to be recorded when the page couldn't be opened")
+	}
+
+	var opened string
+	if err := runOrder(cm, cfg, "", func(url string) error { opened = url; return nil }, now); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opened != "https://example.com" {
+		t.Errorf("Expected the order page to be opened, got '%s'", opened)
+	}
+	last, ok, err := cm.LastReminder()
+	if err != nil || !ok {
+		t.Fatalf("Expected a recorded order, got ok=%v err=%v", ok, err)
+	}
+	if got := formatReminderOutcome(last); got != "ordered from example.com, Mon Oct 19 2026 16:00 (cli)" {
+		t.Errorf("Unexpected formatted outcome '%s'", got)
+	}
+
+	// a named vendor is opened and preselected from then on
+	if err := cfg.AddVendor(config.Vendor{Name: "Foodora", URL: "https://foodora.no"}); err != nil {
+		t.Fatalf("Failed to add vendor: %v", err)
+	}
+	if err := runOrder(cm, cfg, "foodora", func(url string) error { opened = url; return nil }, now); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opened != "https://foodora.no" || cfg.State.LastVendor != "Foodora" {
+		t.Errorf("Expected Foodora to be opened and remembered, got '%s' and '%s'", opened, cfg.State.LastVendor)
+	}
+	if last, _, _ := cm.LastReminder(); last.Vendor != "Foodora" {
+		t.Errorf("Expected the order from Foodora to be recorded, got '%s'", last.Vendor)
+	}
+	if err := runOrder(cm, cfg, "", func(url string) error { opened = url; return nil }, now); err != nil || opened != "https://foodora.no" {
+		t.Errorf("Expected the last vendor to be the default, got '%s' (%v)", opened, err)
+	}
+	if err := runOrder(cm, cfg, "Wolt", func(string) error { return nil }, now); err == nil {
+		t.Error("Expected an error for an unknown vendor")
+	}
+}
+
+func TestFormatReminderOutcome(t *testing.T) {
+	ts := time.Date(2026, 10, 19, 16, 0, 0, 0, time.Local).Unix()
+	tests := []struct {
+		outcome  config.ReminderOutcome
+		expected string
+	}{
+		{config.ReminderOutcome{Timestamp: ts, Notifier: "popup", Action: "order"}, "ordered, Mon Oct 19 2026 16:00 (popup)"},
+		{config.ReminderOutcome{Timestamp: ts, Notifier: "popup", Action: "order", Vendor: "Wolt"}, "ordered from Wolt, Mon Oct 19 2026 16:00 (popup)"},
+		{config.ReminderOutcome{Timestamp: ts, Notifier: "terminal", Action: "skip"}, "skipped, Mon Oct 19 2026 16:00 (terminal)"},
+		{config.ReminderOutcome{Timestamp: ts, Action: "timeout"}, "timed out, Mon Oct 19 2026 16:00"},
+		{config.ReminderOutcome{Timestamp: ts, Notifier: "webhook", Action: "sent"}, "sent, Mon Oct 19 2026 16:00 (webhook)"},
+	}
+
+	for _, tt := range tests {
+		if got := formatReminderOutcome(tt.outcome); got != tt.expected {
+			t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+		}
+	}
+}
+
+func TestReminderModel(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+	friday := time.Date(2026, 10, 23, 16, 0, 0, 0, time.Local)
+	cfg := config.Config{InstallOptions: config.InstallOptions{SiteLink: "https://example.com"}}
+
+	m := reminderModel(cfg, "", friday, logger)
+	defaults := model.New("https://example.com", "")
+	if m.Title != defaults.Title || m.Emoji != defaults.Emoji || m.Timeout != model.DefaultTimeout || m.ImagePath != "" {
+		t.Errorf("Expected the default popup, got %+v", m)
+	}
+
+	image := filepath.Join(t.TempDir(), "logo.png")
+	if err := os.WriteFile(image, []byte("png"), 0644); err != nil {
+		t.Fatalf("Failed to write image: %v", err)
+	}
+	cfg.Popup = config.PopupSettings{
+		Title:           "Team dinner",
+		Emoji:           "🍣",
+		Messages:        []string{"Order something nice"},
+		WeekdayMessages: map[string][]string{"Friday": {"Sushi Friday!"}},
+		ImagePath:       image,
+		OrderLabel:      "Order",
+		SkipLabel:       "Not today",
+		TimeoutSeconds:  config.PopupNeverCloses,
+	}
+
+	m = reminderModel(cfg, "", friday, logger)
+	if m.Title != "Team dinner" || m.Emoji != "🍣" || m.ImagePath != image {
+		t.Errorf("Expected the configured title, emoji and image, got %+v", m)
+	}
+	if m.Message != "Sushi Friday!" {
+		t.Errorf("Expected the Friday message, got '%s'", m.Message)
+	}
+	if m.Timeout != 0 {
+		t.Errorf("Expected the popup to stay open, got timeout %v", m.Timeout)
+	}
+	labels := map[model.Action]string{}
+	for _, b := range m.Buttons {
+		labels[b.Action] = b.Label
+	}
+	if labels[model.ActionOrder] != "Order" || labels[model.ActionSkip] != "Not today" {
+		t.Errorf("Expected the configured button labels, got %v", labels)
+	}
+
+	if m := reminderModel(cfg, "", friday.AddDate(0, 0, 3), logger); m.Message != "Order something nice" {
+		t.Errorf("Expected the general message on Monday, got '%s'", m.Message)
+	}
+
+	// a missing image falls back to the emoji
+	cfg.Popup.ImagePath = filepath.Join(t.TempDir(), "missing.png")
+	if m := reminderModel(cfg, "", friday, logger); m.ImagePath != "" {
+		t.Errorf("Expected no image when the file is missing, got '%s'", m.ImagePath)
+	}
+}
+
+func TestReminderModelVendors(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+	now := time.Date(2026, 10, 23, 16, 0, 0, 0, time.Local)
+
+	// a site link of an older config is the only vendor
+	cfg := config.Config{InstallOptions: config.InstallOptions{SiteLink: "https://www.wolt.com"}}
+	m := reminderModel(cfg, "", now, logger)
+	if len(m.Vendors) != 1 || m.Vendors[0].Name != "wolt.com" || m.OrderURL != "https://www.wolt.com" {
+		t.Errorf("Expected the site link as the only vendor, got %+v", m.Vendors)
+	}
+
+	cfg = config.Config{
+		Vendors: []config.Vendor{
+			{Name: "Wolt", URL: "https://wolt.com"},
+			{Name: "Foodora", URL: "https://foodora.no", Icon: "🛵"},
+		},
+		State: config.State{LastVendor: "Foodora"},
+	}
+	m = reminderModel(cfg, "", now, logger)
+	expected := []model.Vendor{
+		{Name: "Wolt", URL: "https://wolt.com"},
+		{Name: "Foodora", URL: "https://foodora.no", Icon: "🛵"},
+	}
+	if !slices.Equal(m.Vendors, expected) {
+		t.Errorf("Expected vendors %+v, got %+v", expected, m.Vendors)
+	}
+	if m.DefaultVendor != 1 || m.OrderURL != "https://foodora.no" {
+		t.Errorf("Expected the last vendor to be preselected, got %d (%s)", m.DefaultVendor, m.OrderURL)
+	}
+}
+
+func TestReminderModelURLTemplate(t *testing.T) {
+	t.Setenv("SULTENGUTT_TEST_TEAM", "blue team")
+	cfg := config.Config{
+		Vendors: []config.Vendor{
+			{Name: "Canteen", URL: "https://canteen.example.com/menu?date={date}&week={iso_week}&team={env:SULTENGUTT_TEST_TEAM}"},
+		},
+	}
+	friday := time.Date(2026, 10, 16, 16, 0, 0, 0, time.Local)
+
+	m := reminderModel(cfg, "", friday, log.New(io.Discard, "", 0))
+	expected := "https://canteen.example.com/menu?date=2026-10-16&week=42&team=blue+team"
+	if m.OrderURL != expected || m.Vendors[0].URL != expected {
+		t.Errorf("Expected the URL to be expanded to %s, got %s", expected, m.OrderURL)
+	}
+
+	t.Run("broken URLs are left out", func(t *testing.T) {
+		cfg := config.Config{
+			Vendors: []config.Vendor{
+				{Name: "Wolt", URL: "https://wolt.com"},
+				{Name: "Tenant", URL: "https://{env:SULTENGUTT_TEST_UNSET}/menu"},
+				{Name: "Foodora", URL: "https://foodora.no"},
+			},
+			State: config.State{LastVendor: "Foodora"},
+		}
+		m := reminderModel(cfg, "", friday, log.New(io.Discard, "", 0))
+		if len(m.Vendors) != 2 || m.Vendors[m.DefaultVendor].Name != "Foodora" || m.OrderURL != "https://foodora.no" {
+			t.Errorf("Expected Tenant to be left out and Foodora preselected, got %+v (default %d)", m.Vendors, m.DefaultVendor)
+		}
+
+		cfg.Vendors = cfg.Vendors[1:2]
+		m = reminderModel(cfg, "", friday, log.New(io.Discard, "", 0))
+		for _, b := range m.Buttons {
+			if b.Action == model.ActionOrder {
+				t.Errorf("Expected no order button without a usable URL, got %+v", m.Buttons)
+			}
+		}
+		if err := runOrder(nil, &cfg, "Tenant", func(string) error { t.Error("Expected nothing to be opened"); return nil }, friday); err == nil {
+			t.Error("Expected an error ordering from a broken URL")
+		}
+	})
+}
+
+func TestRunVendors(t *testing.T) {
+	cfg := &config.Config{InstallOptions: config.InstallOptions{SiteLink: "https://wolt.com"}}
+
+	if err := runVendorsAdd(cfg, config.Vendor{Name: "Foodora", URL: "https://foodora.no", Icon: "🛵"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.InstallOptions.SiteLink != "" || len(cfg.Vendors) != 2 || cfg.Vendors[0].URL != "https://wolt.com" {
+		t.Errorf("Expected the site link to be migrated before the new vendor, got %+v", cfg.Vendors)
+	}
+	if err := runVendorsAdd(cfg, config.Vendor{Name: "foodora", URL: "https://foodora.se"}); err == nil {
+		t.Error("Expected an error for a duplicate vendor")
+	}
+	if err := runVendorsAdd(cfg, config.Vendor{Name: "Local", URL: "ftp://local"}); err == nil {
+		t.Error("Expected an error for a non-http URL")
+	}
+
+	if err := runVendorsRemove(cfg, "Foodora"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := runVendorsRemove(cfg, "wolt.com"); err == nil {
+		t.Error("Expected an error removing the only vendor")
+	}
+	if err := runVendorsRemove(cfg, "Missing"); err == nil {
+		t.Error("Expected an error for an unknown vendor")
+	}
+}
+
+func TestPopupTheme(t *testing.T) {
+	if got := popupTheme(config.ThemeSettings{}); got != model.DefaultTheme {
+		t.Errorf("Expected the default theme, got %+v", got)
+	}
+
+	got := popupTheme(config.ThemeSettings{
+		Mode:       config.ThemeCustom,
+		Background: "#FFF8E7",
+		Foreground: "#332211",
+		Accent:     "#FF6B6B",
+		FontScale:  1.25,
+	})
+	expected := model.Theme{
+		Mode:      model.ThemeCustom,
+		Custom:    model.NewPalette(color.NRGBA{0xFF, 0xF8, 0xE7, 0xFF}, color.NRGBA{0x33, 0x22, 0x11, 0xFF}),
+		Accent:    color.NRGBA{0xFF, 0x6B, 0x6B, 0xFF},
+		FontScale: 1.25,
+	}
+	if got != expected {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
+
 func TestRunUninstall(t *testing.T) {
 	// Handle potential panic if sultengutt executable not in PATH
 	defer func() {
@@ -379,3 +1126,390 @@ func BenchmarkRunStatus(b *testing.B) {
 		runStatus(cfg)
 	}
 }
+
+func TestWriteScreenshot(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 3))
+	path := filepath.Join(t.TempDir(), "popup.png")
+	if err := writeScreenshot(path, img); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open screenshot: %v", err)
+	}
+	defer f.Close()
+	decoded, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("Expected a PNG file, got %v", err)
+	}
+	if decoded.Bounds() != img.Bounds() {
+		t.Errorf("Expected bounds %v, got %v", img.Bounds(), decoded.Bounds())
+	}
+
+	if err := writeScreenshot(filepath.Join(t.TempDir(), "missing", "popup.png"), img); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+}
+
+func TestFormatPreviewResult(t *testing.T) {
+	tests := []struct {
+		result   model.Result
+		expected string
+	}{
+		{model.Result{Action: model.ActionOrder, Vendor: "Wolt"}, "Order (Wolt)"},
+		{model.Result{Action: model.ActionSnooze, SnoozeFor: 30 * time.Minute}, "Snooze 30 min"},
+		{model.Result{Action: model.ActionSkip}, "Skip"},
+		{model.Result{Action: model.ActionClose}, "closed without choosing"},
+	}
+
+	for _, tt := range tests {
+		if got := formatPreviewResult(tt.result); got != tt.expected {
+			t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+		}
+	}
+}
+
+func TestDeferral(t *testing.T) {
+	loc := time.Local
+	at := func(hour, min int) time.Time { return time.Date(2026, 10, 16, hour, min, 0, 0, loc) }
+	ics := "BEGIN:VCALENDAR\n" +
+		"BEGIN:VEVENT\nSUMMARY:Planning\nDTSTART:20261016T140000\nDTEND:20261016T150000\nEND:VEVENT\n" +
+		"BEGIN:VEVENT\nSUMMARY:Retro\nDTSTART:20261016T150300\nDTEND:20261016T160000\nEND:VEVENT\n" +
+		"BEGIN:VEVENT\nSUMMARY:Offsite\nDTSTART:20261016T170000\nDTEND:20261016T200000\nEND:VEVENT\n" +
+		"END:VCALENDAR\n"
+	path := filepath.Join(t.TempDir(), "work.ics")
+	if err := os.WriteFile(path, []byte(ics), 0644); err != nil {
+		t.Fatalf("Failed to write calendar: %v", err)
+	}
+	cfg := config.Config{Calendar: config.CalendarSettings{Path: path}}
+	meetings, err := cfg.BusyCalendar()
+	if err != nil {
+		t.Fatalf("Failed to load calendar: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		cutoff   string
+		gap      int
+		expected time.Time // zero when the reminder is shown now
+		busy     string
+	}{
+		{"free", at(13, 0), "", 0, time.Time{}, ""},
+		{"next gap", at(14, 30), "", 0, at(16, 0), "Planning"},
+		{"short break is enough", at(14, 30), "", 2, at(15, 0), "Planning"},
+		{"no gap before cutoff", at(14, 30), "15:55", 0, time.Time{}, "Planning"},
+		{"no gap within the default limit", at(17, 30), "", 0, time.Time{}, "Offsite"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := cfg
+			cfg.OrderCutoff = tt.cutoff
+			cfg.Calendar.MinGapMinutes = tt.gap
+			until, busy, ok := deferral(cfg, meetings, tt.now)
+			if busy.Summary != tt.busy {
+				t.Errorf("Expected to be busy with %q, got %q", tt.busy, busy.Summary)
+			}
+			if tt.expected.IsZero() {
+				if ok {
+					t.Errorf("Expected the reminder now, got deferred to %v", until)
+				}
+				return
+			}
+			if !ok || !until.Equal(tt.expected) {
+				t.Errorf("Expected deferral to %v, got %v (deferred: %v)", tt.expected, until, ok)
+			}
+		})
+	}
+
+	if _, _, ok := deferral(config.Config{}, nil, at(14, 30)); ok {
+		t.Error("Expected no deferral without a calendar")
+	}
+}
+
+func TestRunCutoff(t *testing.T) {
+	cfg := &config.Config{InstallOptions: config.InstallOptions{Hour: "11:00"}}
+
+	if err := runCutoff(cfg, "13:30"); err != nil || cfg.OrderCutoff != "13:30" {
+		t.Fatalf("Expected cutoff 13:30, got %q (error: %v)", cfg.OrderCutoff, err)
+	}
+	if err := runCutoff(cfg, "10:00"); err == nil {
+		t.Error("Expected an error for a cutoff before the reminder")
+	}
+	if err := runCutoff(cfg, "noon"); err == nil {
+		t.Error("Expected an error for an invalid cutoff")
+	}
+	if cfg.OrderCutoff != "13:30" {
+		t.Errorf("Expected invalid cutoffs to keep 13:30, got %q", cfg.OrderCutoff)
+	}
+	if err := runCutoff(cfg, "off"); err != nil || cfg.OrderCutoff != "" {
+		t.Errorf("Expected no cutoff, got %q (error: %v)", cfg.OrderCutoff, err)
+	}
+}
+
+func TestRunCalendarSet(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "work.ics")
+	if err := os.WriteFile(valid, []byte("BEGIN:VCALENDAR\nEND:VCALENDAR\n"), 0644); err != nil {
+		t.Fatalf("Failed to write calendar: %v", err)
+	}
+	broken := filepath.Join(dir, "broken.ics")
+	if err := os.WriteFile(broken, []byte("BEGIN:VCALENDAR\nBEGIN:VEVENT\n"), 0644); err != nil {
+		t.Fatalf("Failed to write calendar: %v", err)
+	}
+
+	cfg := &config.Config{}
+	if err := runCalendarSet(cfg, valid, 10); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Calendar.Path != valid || cfg.Calendar.MinGapMinutes != 10 {
+		t.Errorf("Expected calendar %s with a 10 minute gap, got %+v", valid, cfg.Calendar)
+	}
+	for _, path := range []string{broken, filepath.Join(dir, "missing.ics")} {
+		if err := runCalendarSet(cfg, path, 0); err == nil {
+			t.Errorf("Expected an error for %s", path)
+		}
+	}
+	if cfg.Calendar.Path != valid {
+		t.Errorf("Expected failed changes to keep %s, got %s", valid, cfg.Calendar.Path)
+	}
+
+	runCalendarOff(cfg)
+	if cfg.Calendar.Path != "" {
+		t.Errorf("Expected no calendar, got %s", cfg.Calendar.Path)
+	}
+}
+
+func TestRunRefire(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 3, 10, 0, time.Local)
+	cfg := &config.Config{PausedUntil: -1, Refire: config.RefireSettings{AfterMinutes: 15}}
+	snoozer := &fakeSnoozer{}
+
+	until, err := runRefire(cfg, snoozer, now.Add(15*time.Minute), 2, now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := time.Date(2026, 10, 16, 14, 19, 0, 0, time.Local)
+	if !until.Equal(expected) || !snoozer.at.Equal(expected) {
+		t.Errorf("Expected re-fire at %v, got %v (scheduled %v)", expected, until, snoozer.at)
+	}
+	if cfg.State.SnoozedUntil != expected.Unix() || cfg.CurrentAttempt() != 2 {
+		t.Errorf("Expected the re-fire as attempt 2 in the config, got %d at %d", cfg.State.RefireAttempt, cfg.State.SnoozedUntil)
+	}
+
+	cfg = &config.Config{PausedUntil: -1}
+	if _, err := runRefire(cfg, &fakeSnoozer{err: os.ErrPermission}, now.Add(15*time.Minute), 2, now); err == nil {
+		t.Error("Expected an error")
+	}
+	if cfg.State.RefireAttempt != 0 {
+		t.Error("Expected no attempt in the config when scheduling fails")
+	}
+}
+
+func TestRunRefireSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		times    int
+		expected config.RefireSettings
+		wantErr  bool
+	}{
+		{"minutes", []string{"15m"}, 0, config.RefireSettings{AfterMinutes: 15}, false},
+		{"words", []string{"20", "minutes"}, 4, config.RefireSettings{AfterMinutes: 20, MaxAttempts: 4}, false},
+		{"a day", []string{"1", "day"}, 0, config.RefireSettings{}, true},
+		{"seconds", []string{"PT90S"}, 0, config.RefireSettings{}, true},
+		{"too many times", []string{"15m"}, 50, config.RefireSettings{}, true},
+		{"invalid", []string{"soon"}, 0, config.RefireSettings{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			err := runRefireSet(cfg, tt.args, tt.times)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if cfg.Refire != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, cfg.Refire)
+			}
+		})
+	}
+
+	cfg := &config.Config{Refire: config.RefireSettings{AfterMinutes: 15}}
+	runRefireOff(cfg)
+	if cfg.Refire.Enabled() {
+		t.Error("Expected re-firing to be off")
+	}
+}
+
+func TestRunMantraAddRemove(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{}
+	builtin, err := assets.Mantras()
+	if err != nil {
+		t.Fatalf("Failed to load built-in mantras: %v", err)
+	}
+	inPool := func(text string) bool {
+		pool, err := mantraPool(*cfg, dir)
+		if err != nil {
+			t.Fatalf("Failed to load mantras: %v", err)
+		}
+		return slices.Contains(mantraTexts(pool), text)
+	}
+
+	if err := runMantraAdd(cfg, dir, "  Feed the team.  "); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !inPool("Feed the team.") {
+		t.Error("Expected the added mantra in the pool")
+	}
+	if err := runMantraAdd(cfg, dir, "Feed the team."); err == nil {
+		t.Error("Expected an error for a duplicate mantra")
+	}
+	if err := runMantraAdd(cfg, dir, builtin[0]); err == nil {
+		t.Error("Expected an error for a built-in mantra")
+	}
+	if err := runMantraAdd(cfg, dir, ""); err == nil {
+		t.Error("Expected an error for an empty mantra")
+	}
+
+	// by text from a pack
+	if err := runMantraRemove(cfg, dir, "Feed the team."); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if inPool("Feed the team.") {
+		t.Error("Expected the mantra to be removed from the pack")
+	}
+
+	// by number, built-in mantras are hidden
+	if err := runMantraRemove(cfg, dir, "1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if inPool(builtin[0]) || !slices.Equal(cfg.Mantras.Hidden, []string{builtin[0]}) {
+		t.Errorf("Expected %q to be hidden, got %v", builtin[0], cfg.Mantras.Hidden)
+	}
+	for _, arg := range []string{"0", "10000", "No such mantra"} {
+		if err := runMantraRemove(cfg, dir, arg); err == nil {
+			t.Errorf("Expected an error removing %q", arg)
+		}
+	}
+
+	// adding a hidden mantra brings it back
+	if err := runMantraAdd(cfg, dir, builtin[0]); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !inPool(builtin[0]) || len(cfg.Mantras.Hidden) != 0 {
+		t.Errorf("Expected %q to be restored, hidden: %v", builtin[0], cfg.Mantras.Hidden)
+	}
+}
+
+func TestRunMantraImport(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(t.TempDir(), "stoic.json")
+	if err := os.WriteFile(src, []byte(`["Amor fati.", "Memento mori."]`), 0644); err != nil {
+		t.Fatalf("Failed to write pack: %v", err)
+	}
+
+	if err := runMantraImport(dir, src, ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := runMantraImport(dir, src, "classics"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	packs, err := config.LoadMantraPacks(dir)
+	if err != nil {
+		t.Fatalf("Failed to load packs: %v", err)
+	}
+	if len(packs) != 2 || packs[0].Name != "classics" || packs[1].Name != "stoic" || len(packs[1].Mantras) != 2 {
+		t.Errorf("Expected the classics and stoic packs, got %+v", packs)
+	}
+
+	cfg := config.Config{Mantras: config.MantraSettings{Replace: true}}
+	pool, _ := mantraPool(cfg, dir)
+	if !slices.Equal(mantraTexts(pool), []string{"Amor fati.", "Memento mori."}) {
+		t.Errorf("Expected only the imported mantras, got %v", mantraTexts(pool))
+	}
+
+	for name, data := range map[string]string{"empty.json": `[]`, "object.json": `{"a": "b"}`} {
+		path := filepath.Join(t.TempDir(), name)
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatalf("Failed to write pack: %v", err)
+		}
+		if err := runMantraImport(dir, path, ""); err == nil {
+			t.Errorf("Expected an error importing %s", name)
+		}
+	}
+	if err := runMantraImport(dir, filepath.Join(dir, "missing.json"), ""); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestRunMantraBuiltin(t *testing.T) {
+	cfg := &config.Config{}
+	if err := runMantraBuiltin(cfg, "off"); err != nil || !cfg.Mantras.Replace {
+		t.Errorf("Expected the built-in mantras to be replaced (error: %v)", err)
+	}
+	if err := runMantraBuiltin(cfg, "on"); err != nil || cfg.Mantras.Replace {
+		t.Errorf("Expected the built-in mantras to be shown (error: %v)", err)
+	}
+	if err := runMantraBuiltin(cfg, "maybe"); err == nil {
+		t.Error("Expected an error")
+	}
+}
+
+func TestTodaysMantra(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cm, err := config.NewConfigManager()
+	if err != nil {
+		t.Fatalf("Failed to create config manager: %v", err)
+	}
+	if err := os.MkdirAll(cm.ConfigDir(), 0755); err != nil {
+		t.Fatalf("Failed to create config directory: %v", err)
+	}
+	cfg := config.Config{}
+	morning := time.Date(2026, 10, 19, 9, 0, 0, 0, time.Local)
+
+	first, err := todaysMantra(cm, &cfg, morning)
+	if err != nil || first == "" {
+		t.Fatalf("Expected a mantra, got '%s' (error: %v)", first, err)
+	}
+	if again, err := todaysMantra(cm, &cfg, morning.Add(7*time.Hour)); err != nil || again != first {
+		t.Errorf("Expected '%s' all day, got '%s' (error: %v)", first, again, err)
+	}
+	if next, err := todaysMantra(cm, &cfg, morning.AddDate(0, 0, 1)); err != nil || next == first {
+		t.Errorf("Expected another mantra the next day, got '%s' (error: %v)", next, err)
+	}
+}
+
+func TestCommandLine(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"pause", "1", "day"}, "sultengutt pause 1 day"},
+		{[]string{"pause", "--reason", "team trip", "--quiet", "2", "weeks"}, `sultengutt pause --quiet --reason="team trip" 2 weeks`},
+		{[]string{"pause", "--times=3", "until friday"}, `sultengutt pause --times=3 "until friday"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			root := &cobra.Command{Use: "sultengutt"}
+			pause := &cobra.Command{Use: "pause"}
+			pause.Flags().String("reason", "", "")
+			pause.Flags().Bool("quiet", false, "")
+			pause.Flags().Int("times", 0, "")
+			root.AddCommand(pause)
+
+			cmd, args, err := root.Find(tt.args)
+			if err != nil {
+				t.Fatalf("Failed to find command: %v", err)
+			}
+			if err := cmd.ParseFlags(args); err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+			if got := commandLine(cmd, cmd.Flags().Args()); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
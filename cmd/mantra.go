@@ -0,0 +1,183 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sultengutt/assets"
+	"sultengutt/internal/config"
+	"time"
+)
+
+// mantraPool returns the mantras the reminder picks from. When the packs can't be
+// read, the error comes with the built-in mantras, so the reminder still gets one.
+func mantraPool(cfg config.Config, dir string) ([]config.Mantra, error) {
+	packs, err := config.LoadMantraPacks(dir)
+	return cfg.MantraPool(packs), err
+}
+
+func mantraTexts(pool []config.Mantra) []string {
+	texts := make([]string, 0, len(pool))
+	for _, m := range pool {
+		texts = append(texts, m.Text)
+	}
+	return texts
+}
+
+// todaysMantra returns the mantra of the day from the rotation: the same for every
+// reminder on a day, and none repeats before every mantra has been shown. Without a
+// config to keep the rotation in, i.e. before installing, it falls back to a mantra
+// derived from the date. It is empty when there are no mantras.
+func todaysMantra(cm *config.ConfigManager, cfg *config.Config, now time.Time) (string, error) {
+	pool, poolErr := mantraPool(*cfg, cfg.MantraPackDir())
+	loader := assets.NewMantraLoaderFrom(mantraTexts(pool))
+	if cfg.IsFreshInstall() {
+		return loader.MantraOfTheDay(now), poolErr
+	}
+	mantra := loader.Next(&cfg.State.MantraRotation, now)
+	if err := cm.Save(cfg); err != nil {
+		return mantra, errors.Join(poolErr, err)
+	}
+	return mantra, poolErr
+}
+
+func runMantraList(cfg config.Config, dir string) error {
+	pool, err := mantraPool(cfg, dir)
+	if err != nil {
+		return err
+	}
+	if cfg.Mantras.Replace {
+		fmt.Println("Mantras from your packs, replacing the built-in ones:")
+	} else {
+		fmt.Println("Mantras:")
+	}
+	width := len(strconv.Itoa(len(pool)))
+	for i, m := range pool {
+		fmt.Printf("  %*d. %s  %s\n", width, i+1, m.Text, infoStyle.Render("("+m.Pack+")"))
+	}
+	return nil
+}
+
+func runMantraAdd(cfg *config.Config, dir, text string) error {
+	text = strings.TrimSpace(text)
+	if err := config.ValidateMantra(text); err != nil {
+		return err
+	}
+	if i := slices.Index(cfg.Mantras.Hidden, text); i >= 0 {
+		// removed before, bring the built-in mantra back
+		cfg.Mantras.Hidden = slices.Delete(cfg.Mantras.Hidden, i, i+1)
+		fmt.Println(successStyle.Render("✓ Restored mantra: " + text))
+		return nil
+	}
+
+	packs, err := config.LoadMantraPacks(dir)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(mantraTexts(cfg.MantraPool(packs)), text) {
+		return fmt.Errorf("already a mantra: %s", text)
+	}
+	custom := config.MantraPack{Name: config.CustomMantraPack}
+	for _, p := range packs {
+		if p.Name == config.CustomMantraPack {
+			custom = p
+		}
+	}
+	custom.Mantras = append(custom.Mantras, text)
+	if err := config.SaveMantraPack(dir, custom); err != nil {
+		return err
+	}
+	fmt.Println(successStyle.Render("✓ Added mantra: " + text))
+	return nil
+}
+
+// runMantraRemove removes a mantra by its number in 'sultengutt mantra list' or its
+// text. Mantras of the user's packs are deleted from them, built-in ones are hidden.
+func runMantraRemove(cfg *config.Config, dir, arg string) error {
+	packs, err := config.LoadMantraPacks(dir)
+	if err != nil {
+		return err
+	}
+	pool := cfg.MantraPool(packs)
+
+	text := arg
+	if n, err := strconv.Atoi(arg); err == nil {
+		if n < 1 || n > len(pool) {
+			return fmt.Errorf("no mantra number %d, see 'sultengutt mantra list'", n)
+		}
+		text = pool[n-1].Text
+	} else if !slices.Contains(mantraTexts(pool), text) {
+		return fmt.Errorf("no such mantra: %s", text)
+	}
+
+	for _, p := range packs {
+		if slices.Contains(p.Mantras, text) {
+			p.Mantras = slices.DeleteFunc(p.Mantras, func(m string) bool { return m == text })
+			if err := config.SaveMantraPack(dir, p); err != nil {
+				return err
+			}
+		}
+	}
+	if builtin, _ := assets.Mantras(); slices.Contains(builtin, text) {
+		cfg.HideMantra(text)
+	}
+	fmt.Println(successStyle.Render("✓ Removed mantra: " + text))
+	return nil
+}
+
+// runMantraImport copies a JSON list of mantras into a pack, named after the file
+// unless a name is given
+func runMantraImport(dir, path, name string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read mantra pack: %w", err)
+	}
+	mantras, err := config.ParseMantraPack(data)
+	if err != nil {
+		return err
+	}
+	if len(mantras) == 0 {
+		return errors.New("the pack has no mantras")
+	}
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if err := config.SaveMantraPack(dir, config.MantraPack{Name: name, Mantras: mantras}); err != nil {
+		return err
+	}
+	fmt.Println(successStyle.Render(fmt.Sprintf("✓ Imported %d mantras as pack %s", len(mantras), name)))
+	return nil
+}
+
+func runMantraToday(cm *config.ConfigManager, cfg *config.Config, now time.Time) error {
+	mantra, err := todaysMantra(cm, cfg, now)
+	if mantra == "" {
+		if err != nil {
+			return err
+		}
+		return errors.New("no mantras, add one with 'sultengutt mantra add'")
+	}
+	if err != nil {
+		fmt.Println(infoStyle.Render("Warning: " + err.Error()))
+	}
+	fmt.Printf("Your mantra for %s:\n\n  \"%s\"\n", now.Format("Monday"), mantra)
+	return nil
+}
+
+func runMantraBuiltin(cfg *config.Config, value string) error {
+	switch value {
+	case "on":
+		cfg.Mantras.Replace = false
+		fmt.Println(successStyle.Render("✓ The built-in mantras are shown along with your packs"))
+	case "off":
+		cfg.Mantras.Replace = true
+		fmt.Println(successStyle.Render("✓ Only the mantras of your packs are shown, once there are any"))
+	default:
+		return fmt.Errorf("use on or off, not %s", value)
+	}
+	return nil
+}
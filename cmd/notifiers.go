@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sultengutt/internal/config"
+)
+
+func runNotifiersList(cfg config.Config) {
+	order := cfg.NotifierOrder()
+	fmt.Println("Notifiers are tried in this order until one delivers the reminder:")
+	for i, name := range order {
+		fmt.Printf("  %d. %s%s\n", i+1, name, notifierDetail(cfg, name))
+	}
+
+	var unused []string
+	for _, name := range config.Notifiers {
+		if !slices.Contains(order, name) {
+			unused = append(unused, name)
+		}
+	}
+	if len(unused) > 0 {
+		fmt.Println(infoStyle.Render("Not used: " + strings.Join(unused, ", ")))
+	}
+}
+
+func notifierDetail(cfg config.Config, name string) string {
+	switch name {
+	case config.NotifierCommand:
+		return " (" + cfg.Notifiers.Command + ")"
+	case config.NotifierWebhook:
+		return " (" + cfg.Notifiers.Webhook + ")"
+	}
+	return ""
+}
+
+func runNotifiersOrder(cfg *config.Config, names []string) error {
+	if len(names) == 1 && names[0] == "default" {
+		names = nil
+	}
+	if err := cfg.SetNotifierOrder(names); err != nil {
+		return err
+	}
+	fmt.Println(successStyle.Render("✓ Notifier order: " + strings.Join(cfg.NotifierOrder(), ", ")))
+	return nil
+}
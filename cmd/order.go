@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sultengutt/internal/config"
+	"sultengutt/internal/notify"
+	"sultengutt/internal/popup/model"
+	"time"
+)
+
+// orderNotifier is recorded as the notifier of orders made with 'sultengutt order'
+const orderNotifier = "cli"
+
+// runOrder opens the order page of a vendor, the default one when name is empty,
+// and records the order in the reminder log
+func runOrder(cm *config.ConfigManager, cfg *config.Config, name string, open func(string) error, now time.Time) error {
+	vendors := cfg.OrderVendors()
+	if len(vendors) == 0 {
+		return errors.New("no vendor to order from")
+	}
+	vendor := vendors[cfg.DefaultVendor()]
+	if name != "" {
+		v, ok := cfg.FindVendor(name)
+		if !ok {
+			return fmt.Errorf("no vendor named %s (see 'sultengutt vendors')", name)
+		}
+		vendor = v
+	}
+	link, err := orderURL(vendor, now)
+	if err != nil {
+		return err
+	}
+	if err := open(link); err != nil {
+		return err
+	}
+	fmt.Println(successStyle.Render("✓ Opened " + link))
+
+	outcome := notify.Outcome{Notifier: orderNotifier, Result: model.Result{Action: model.ActionOrder, Vendor: vendor.Name}}
+	if err := recordOutcome(cm, outcome, now); err != nil {
+		return fmt.Errorf("failed to record order: %w", err)
+	}
+	cfg.SetLastVendor(vendor.Name)
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"sultengutt/internal/config"
+	"sultengutt/internal/notify"
+	"sultengutt/internal/popup/model"
+	"time"
+)
+
+// recordOutcome saves how the reminder ended in the reminder log
+func recordOutcome(cm *config.ConfigManager, outcome notify.Outcome, now time.Time) error {
+	return cm.RecordReminderOutcome(config.ReminderOutcome{
+		Timestamp: now.Unix(),
+		Notifier:  outcome.Notifier,
+		Action:    string(outcome.Action),
+		SnoozeFor: int64(outcome.SnoozeFor.Seconds()),
+		Vendor:    outcome.Vendor,
+	})
+}
+
+func formatReminderOutcome(o config.ReminderOutcome) string {
+	var what string
+	switch model.Action(o.Action) {
+	case model.ActionOrder:
+		what = "ordered"
+		if o.Vendor != "" {
+			what += " from " + o.Vendor
+		}
+	case model.ActionSkip:
+		what = "skipped"
+	case model.ActionSnooze:
+		what = "snoozed"
+		if o.SnoozeFor > 0 {
+			what += fmt.Sprintf(" for %d min", o.SnoozeFor/60)
+		}
+	case model.ActionTimeout:
+		what = "timed out"
+	case model.ActionClose:
+		what = "closed without choosing"
+	case model.ActionSent:
+		what = "sent"
+	default:
+		what = o.Action
+	}
+
+	line := what + ", " + time.Unix(o.Timestamp, 0).Format(windowTimeFormat)
+	if o.Notifier != "" {
+		line += " (" + o.Notifier + ")"
+	}
+	return line
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"sultengutt/internal/config"
+	"sultengutt/internal/utils"
+	"time"
+)
+
+const windowTimeFormat = "Mon Jan 2 2006 15:04"
+
+// runPauseAdd plans a pause between two dates. Dates without a time cover the whole day,
+// so "--from 2026-12-21 --to 2026-12-27" pauses from the start of the 21st to the end of the 27th.
+func runPauseAdd(cfg *config.Config, fromStr, toStr, reason string, now time.Time) (config.PauseWindow, error) {
+	from, _, err := utils.ParseDate(fromStr, now.Location())
+	if err != nil {
+		return config.PauseWindow{}, fmt.Errorf("error parsing --from: %v", err)
+	}
+	to, hasTime, err := utils.ParseDate(toStr, now.Location())
+	if err != nil {
+		return config.PauseWindow{}, fmt.Errorf("error parsing --to: %v", err)
+	}
+	if !hasTime {
+		to = to.AddDate(0, 0, 1)
+	}
+	if !to.After(now) {
+		return config.PauseWindow{}, fmt.Errorf("planned pause ends in the past: %s", to.Format(windowTimeFormat))
+	}
+
+	window, err := cfg.AddPauseWindow(from, to, reason)
+	if err != nil {
+		return config.PauseWindow{}, err
+	}
+
+	fmt.Printf("Planned pause %d from %s until %s\n", window.ID,
+		time.Unix(window.From, 0).Format(windowTimeFormat),
+		time.Unix(window.To, 0).Format(windowTimeFormat))
+	return window, nil
+}
+
+func runPauseList(cfg config.Config) {
+	if len(cfg.PauseWindows) == 0 {
+		fmt.Println(infoStyle.Render("No planned pauses. Use 'sultengutt pause add' to plan one."))
+		return
+	}
+
+	fmt.Println("Planned pauses:")
+	for _, w := range cfg.PauseWindows {
+		fmt.Println("  " + formatPauseWindow(w))
+	}
+}
+
+func runPauseRemove(cfg *config.Config, id int) (config.PauseWindow, error) {
+	w, err := cfg.RemovePauseWindow(id)
+	if err != nil {
+		return config.PauseWindow{}, err
+	}
+	fmt.Println(infoStyle.Render(fmt.Sprintf("Removed planned pause %d", id)))
+	return w, nil
+}
+
+// pauseWindowEvent returns the pause log event for planning or removing a planned pause
+func pauseWindowEvent(action string, w config.PauseWindow, now time.Time) config.PauseEvent {
+	return config.PauseEvent{
+		Timestamp: now.Unix(),
+		Action:    action,
+		User:      currentUser(),
+		Reason:    w.Reason,
+		From:      w.From,
+		Until:     w.To,
+		Window:    w.ID,
+	}
+}
+
+func formatPauseWindow(w config.PauseWindow) string {
+	line := fmt.Sprintf("%d: %s → %s", w.ID,
+		time.Unix(w.From, 0).Format(windowTimeFormat),
+		time.Unix(w.To, 0).Format(windowTimeFormat))
+	if w.Reason != "" {
+		line += " (" + w.Reason + ")"
+	}
+	return line
+}
+
+func runPauseLog(cm *config.ConfigManager, limit int) error {
+	events, err := cm.PauseLog()
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		fmt.Println(infoStyle.Render("No pauses recorded yet"))
+		return nil
+	}
+	if limit > 0 && len(events) > limit {
+		events = events[len(events)-limit:]
+	}
+
+	for _, e := range events {
+		fmt.Println(formatPauseEvent(e))
+	}
+	return nil
+}
+
+func formatPauseEvent(e config.PauseEvent) string {
+	var what string
+	switch e.Action {
+	case config.PauseActionPause:
+		switch {
+		case e.Until == 0:
+			what = "paused indefinitely"
+		case e.Until > 0:
+			what = "paused until " + time.Unix(e.Until, 0).Format(windowTimeFormat)
+		default:
+			what = "paused"
+		}
+	case config.PauseActionResume:
+		what = "resumed"
+	case config.PauseActionAutoResume:
+		what = "resumed automatically"
+	case config.PauseActionPlan, config.PauseActionUnplan:
+		what = fmt.Sprintf("planned pause %d from %s until %s", e.Window,
+			time.Unix(e.From, 0).Format(windowTimeFormat), time.Unix(e.Until, 0).Format(windowTimeFormat))
+		if e.Action == config.PauseActionUnplan {
+			what = "removed " + what
+		}
+	default:
+		what = e.Action
+	}
+
+	who := e.User
+	if who == "" {
+		who = "unknown"
+	}
+	line := fmt.Sprintf("%s  %-12s %s", time.Unix(e.Timestamp, 0).Format("2006-01-02 15:04"), who, what)
+	if e.Reason != "" {
+		line += " (" + e.Reason + ")"
+	}
+	return line
+}
+
+// currentUser returns the name of the user running Sultengutt, for the pause log
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME")
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"slices"
+	"sultengutt/internal/config"
+	"sultengutt/internal/popup/model"
+	"time"
+)
+
+// reminderModel builds the reminder shown at now from the defaults and the popup
+// settings, so every notifier shows the same content. An empty mantra shows the
+// model's fallback one.
+func reminderModel(cfg config.Config, mantra string, now time.Time, logger *log.Logger) model.Model {
+	vendors := cfg.OrderVendors()
+	m := model.New("", mantra)
+	for i, v := range vendors {
+		link, err := orderURL(v, now)
+		if err != nil {
+			// ordering from a broken URL would only open an error page
+			logger.Printf("%v, leaving %s out of the reminder", err, v.Name)
+			continue
+		}
+		if i == cfg.DefaultVendor() {
+			m.DefaultVendor = len(m.Vendors)
+		}
+		m.Vendors = append(m.Vendors, model.Vendor{Name: v.Name, URL: link, Icon: v.Icon})
+	}
+	if len(m.Vendors) > 0 {
+		m.OrderURL = m.Vendors[m.DefaultVendor].URL
+	} else {
+		m.Buttons = slices.DeleteFunc(m.Buttons, func(b model.Button) bool { return b.Action == model.ActionOrder })
+	}
+	m.SnoozeOptions = snoozeOptions(cfg)
+
+	p := cfg.Popup
+	if p.Title != "" {
+		m.Title = p.Title
+	}
+	if p.Emoji != "" {
+		m.Emoji = p.Emoji
+	}
+	if messages := p.MessagesFor(now.Weekday()); messages != nil {
+		m.Message = messages[rand.Intn(len(messages))]
+	}
+	if timeout, ok := p.Timeout(); ok {
+		m.Timeout = timeout
+	}
+	for i, b := range m.Buttons {
+		switch {
+		case b.Action == model.ActionOrder && p.OrderLabel != "":
+			m.Buttons[i].Label = p.OrderLabel
+		case b.Action == model.ActionSkip && p.SkipLabel != "":
+			m.Buttons[i].Label = p.SkipLabel
+		}
+	}
+	m.Theme = popupTheme(p.Theme)
+	if p.ImagePath != "" {
+		// a missing image shouldn't cost the reminder, the emoji is shown instead
+		if _, err := os.Stat(p.ImagePath); err != nil {
+			logger.Printf("failed to load popup image: %v", err)
+		} else {
+			m.ImagePath = p.ImagePath
+		}
+	}
+	return m
+}
+
+// orderURL expands the placeholders in a vendor's URL for an order at now
+func orderURL(v config.Vendor, now time.Time) (string, error) {
+	link, err := config.ExpandOrderURL(v.URL, config.URLContext{Now: now, User: currentUser(), Getenv: os.Getenv})
+	if err != nil {
+		return "", fmt.Errorf("failed to expand the URL of %s: %w", v.Name, err)
+	}
+	return link, nil
+}
+
+// popupTheme turns the theme settings into the popup theme. The settings were
+// validated when the config was loaded.
+func popupTheme(s config.ThemeSettings) model.Theme {
+	t := model.DefaultTheme
+	if s.Mode != "" {
+		t.Mode = model.ThemeMode(s.Mode)
+	}
+	if t.Mode == model.ThemeCustom {
+		background, _ := config.ParseColor(s.Background)
+		foreground, _ := config.ParseColor(s.Foreground)
+		t.Custom = model.NewPalette(background, foreground)
+	}
+	if s.Accent != "" {
+		t.Accent, _ = config.ParseColor(s.Accent)
+	}
+	t.FontScale = float32(s.FontScale)
+	return t
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"sultengutt/internal/popup/model"
+)
+
+// writeScreenshot saves a rendered popup as a PNG file
+func writeScreenshot(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create screenshot: %w", err)
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write screenshot: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write screenshot: %w", err)
+	}
+	return nil
+}
+
+// formatPreviewResult describes how a previewed reminder was closed
+func formatPreviewResult(r model.Result) string {
+	switch r.Action {
+	case model.ActionOrder:
+		if r.Vendor != "" {
+			return "Order (" + r.Vendor + ")"
+		}
+		return "Order"
+	case model.ActionSnooze:
+		return model.SnoozeLabel(r.SnoozeFor)
+	case model.ActionSkip:
+		return "Skip"
+	case model.ActionTimeout:
+		return "closed by itself after the timeout"
+	}
+	return "closed without choosing"
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"sultengutt/internal/config"
+	"sultengutt/internal/scheduler"
+	"sultengutt/internal/utils"
+	"time"
+)
+
+// runRefire schedules a reminder that timed out to be shown again at at, as the
+// attempt-th showing. It returns the time it is shown again.
+func runRefire(cfg *config.Config, snoozer scheduler.Snoozer, at time.Time, attempt int, now time.Time) (time.Time, error) {
+	until, err := runSnooze(cfg, snoozer, at.Sub(now), now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	cfg.State.RefireAttempt = attempt
+	return until, nil
+}
+
+func runRefireShow(cfg config.Config) {
+	if !cfg.Refire.Enabled() {
+		fmt.Println("Reminders that time out are not shown again. Use 'sultengutt refire 15m' to turn it on.")
+		return
+	}
+	fmt.Printf("Reminders that time out are shown again after %d minutes, up to %d times in total\n",
+		cfg.Refire.AfterMinutes, cfg.Refire.Attempts())
+	if _, ok := cfg.Cutoff(time.Now()); ok {
+		fmt.Printf("but not after the order cutoff at %s\n", cfg.OrderCutoff)
+	}
+	printCutoffWarning(cfg)
+}
+
+// runRefireSet turns re-firing on. args is the delay, e.g. 15m or 15 minutes.
+func runRefireSet(cfg *config.Config, args []string, attempts int) error {
+	d, err := utils.ParseDuration(args)
+	if err != nil {
+		return fmt.Errorf("error parsing duration: %v", err)
+	}
+	if !d.IsSubDay() || d.Clock < time.Minute || d.Clock%time.Minute != 0 {
+		return fmt.Errorf("the delay must be whole minutes shorter than a day")
+	}
+	if err := cfg.SetRefire(config.RefireSettings{AfterMinutes: int(d.Clock.Minutes()), MaxAttempts: attempts}); err != nil {
+		return err
+	}
+	fmt.Println(successStyle.Render(fmt.Sprintf("✓ Reminders that time out are shown again after %d minutes, up to %d times",
+		cfg.Refire.AfterMinutes, cfg.Refire.Attempts())))
+	return nil
+}
+
+func runRefireOff(cfg *config.Config) {
+	cfg.Refire = config.RefireSettings{}
+	fmt.Println(infoStyle.Render("Reminders that time out are no longer shown again"))
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sultengutt/internal/config"
+	"sultengutt/internal/utils"
+	"time"
+)
+
+const skipTimeFormat = "Monday, January 2, 2006 15:04"
+
+// runSkipNext skips the next n reminders that would otherwise fire
+func runSkipNext(cfg *config.Config, n int, now time.Time) error {
+	if n < 1 {
+		return fmt.Errorf("number of reminders to skip must be at least 1")
+	}
+
+	if cfg.PausedUntil == 0 {
+		return fmt.Errorf("reminders are paused until you resume them, there is nothing to skip")
+	}
+	reminders, err := cfg.NextReminders(now, n)
+	if err != nil {
+		return fmt.Errorf("failed to calculate upcoming reminders: %w", err)
+	}
+	if len(reminders) == 0 {
+		return fmt.Errorf("no upcoming reminders to skip")
+	}
+
+	for _, r := range reminders {
+		cfg.AddSkip(r)
+		fmt.Println("Skipping reminder on " + r.Format(skipTimeFormat))
+	}
+	return nil
+}
+
+// runSkipDate skips the reminder on a given date, which must be one of the scheduled days
+func runSkipDate(cfg *config.Config, dateStr string, now time.Time) error {
+	date, hasTime, err := utils.ParseDate(dateStr, now.Location())
+	if err != nil || hasTime {
+		return fmt.Errorf("invalid date: %s (expected YYYY-MM-DD)", dateStr)
+	}
+
+	if !slices.Contains(cfg.InstallOptions.Days, date.Weekday().String()) {
+		return fmt.Errorf("there is no reminder on %s, reminders are scheduled on %v", date.Format("Monday, January 2"), cfg.InstallOptions.Days)
+	}
+
+	// the reminder that day must still be ahead of us
+	occurrences, err := utils.NextOccurrences(cfg.InstallOptions.Days, cfg.InstallOptions.Hour, date.Add(-time.Second), 1)
+	if err != nil {
+		return err
+	}
+	if !occurrences[0].After(now) {
+		return fmt.Errorf("the reminder on %s has already fired", date.Format("Monday, January 2"))
+	}
+
+	if !cfg.AddSkip(occurrences[0]) {
+		fmt.Println("The reminder on " + occurrences[0].Format(skipTimeFormat) + " is already skipped")
+		return nil
+	}
+	fmt.Println("Skipping reminder on " + occurrences[0].Format(skipTimeFormat))
+	return nil
+}
+
+func runSkipRemove(cfg *config.Config, date string) error {
+	if err := cfg.RemoveSkip(date); err != nil {
+		return err
+	}
+	fmt.Println(infoStyle.Render("The reminder on " + date + " is no longer skipped"))
+	return nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"sultengutt/internal/config"
+	"sultengutt/internal/popup/model"
+	"sultengutt/internal/scheduler"
+	"sultengutt/internal/utils"
+	"time"
+)
+
+const snoozeTimeFormat = "15:04"
+
+// snoozeOptions returns the snooze durations offered in the reminder
+func snoozeOptions(cfg config.Config) []time.Duration {
+	if d := cfg.SnoozeDurations(); d != nil {
+		return d
+	}
+	return model.DefaultSnoozeOptions
+}
+
+// parseSnoozeDuration parses the duration given to 'sultengutt snooze', defaulting
+// to the first snooze option
+func parseSnoozeDuration(cfg config.Config, args []string) (time.Duration, error) {
+	if len(args) == 0 {
+		options := snoozeOptions(cfg)
+		if len(options) == 0 {
+			return 0, fmt.Errorf("snoozing is disabled, give a duration like 'sultengutt snooze 30m'")
+		}
+		return options[0], nil
+	}
+
+	d, err := utils.ParseDuration(args)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing duration: %v", err)
+	}
+	if !d.IsSubDay() {
+		return 0, fmt.Errorf("a snooze must be shorter than a day, use 'sultengutt pause' for longer breaks")
+	}
+	return d.Clock, nil
+}
+
+// runSnooze snoozes the reminder for d and schedules a one-off reminder for when the
+// snooze ends. It returns the time the reminder is shown again.
+func runSnooze(cfg *config.Config, snoozer scheduler.Snoozer, d time.Duration, now time.Time) (time.Time, error) {
+	// schedulers work in whole minutes, so round up rather than fire early
+	until := now.Add(d)
+	if rounded := until.Truncate(time.Minute); rounded.Before(until) {
+		until = rounded.Add(time.Minute)
+	}
+
+	if err := snoozer.Snooze(until); err != nil {
+		return time.Time{}, err
+	}
+	cfg.SnoozeUntil(until)
+	return until, nil
+}
+
+func runUnsnooze(cfg *config.Config, snoozer scheduler.Snoozer, now time.Time) error {
+	if !cfg.IsSnoozed(now) {
+		fmt.Println(infoStyle.Render("The reminder is not snoozed"))
+		return nil
+	}
+	if err := snoozer.CancelSnooze(); err != nil {
+		return err
+	}
+	cfg.Unsnooze()
+	fmt.Println(successStyle.Render("✓ Snooze cancelled"))
+	return nil
+}
+
+// snoozeDue reports whether a snoozed run should show the reminder. at is the end of
+// the snooze the run was started for, or 0 if the scheduler doesn't know it.
+func snoozeDue(cfg config.Config, at int64, now time.Time) bool {
+	if cfg.State.SnoozedUntil == 0 {
+		return false
+	}
+	if at > 0 {
+		return cfg.State.SnoozedUntil == at
+	}
+	// allow for schedulers firing a little early
+	return cfg.State.SnoozedUntil <= now.Add(time.Minute).Unix()
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"sultengutt/internal/config"
+)
+
+func runVendorsList(cfg config.Config) {
+	vendors := cfg.OrderVendors()
+	if len(vendors) == 0 {
+		fmt.Println("No vendors yet. Use 'sultengutt vendors add' to add one.")
+		return
+	}
+	def := cfg.DefaultVendor()
+	fmt.Println("Vendors to order from:")
+	for i, v := range vendors {
+		name := v.Name
+		if v.Icon != "" {
+			name = v.Icon + " " + name
+		}
+		line := fmt.Sprintf("  %s  %s", name, v.URL)
+		if i == def {
+			line += infoStyle.Render("  (default)")
+		}
+		fmt.Println(line)
+	}
+}
+
+func runVendorsAdd(cfg *config.Config, v config.Vendor) error {
+	if err := cfg.AddVendor(v); err != nil {
+		return err
+	}
+	fmt.Println(successStyle.Render("✓ Added vendor " + v.Name))
+	return nil
+}
+
+func runVendorsRemove(cfg *config.Config, name string) error {
+	if err := cfg.RemoveVendor(name); err != nil {
+		return err
+	}
+	fmt.Println(successStyle.Render("✓ Removed vendor " + name))
+	return nil
+}
@@ -0,0 +1,151 @@
+package calendar
+
+import (
+	"sort"
+	"time"
+)
+
+// Calendar is the events of an iCalendar file
+type Calendar struct {
+	Events []Event
+
+	// Unsupported is the summaries of recurring events whose rules aren't
+	// understood. Only their first occurrence is known.
+	Unsupported []string
+
+	overrides []override
+}
+
+// Event is an event of the calendar. For recurring events it is the first
+// occurrence, Occurrences returns the others.
+type Event struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	AllDay  bool
+	Free    bool // shown as free time, like transparent and all-day events
+
+	duration time.Duration
+	rule     *rule
+	exdates  []time.Time
+}
+
+// End returns when the event ends
+func (e Event) End() time.Time {
+	return e.Start.Add(e.duration)
+}
+
+// override is an occurrence of a recurring event that was moved or changed, and so
+// is an event of its own
+type override struct {
+	uid          string
+	recurrenceID time.Time
+}
+
+// applyOverrides removes the occurrences that were moved from their recurring event
+func (c *Calendar) applyOverrides() {
+	for _, o := range c.overrides {
+		for i := range c.Events {
+			if e := &c.Events[i]; e.UID == o.uid && e.rule != nil {
+				e.exdates = append(e.exdates, o.recurrenceID)
+			}
+		}
+	}
+}
+
+// Occurrences returns the occurrences of the events that overlap [from, to),
+// ordered by start
+func (c *Calendar) Occurrences(from, to time.Time) []Event {
+	var out []Event
+	for _, e := range c.Events {
+		if e.rule == nil {
+			if overlaps(e, from, to) {
+				out = append(out, e)
+			}
+			continue
+		}
+		e.rule.starts(e.Start, func(start time.Time) bool {
+			if !start.Before(to) {
+				return false
+			}
+			occ := e
+			occ.Start = start
+			occ.rule = nil
+			if overlaps(occ, from, to) && !e.excluded(start) {
+				out = append(out, occ)
+			}
+			return true
+		})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Start.Before(out[j].Start) })
+	return out
+}
+
+// BusyAt returns the busy event taking place at t
+func (c *Calendar) BusyAt(t time.Time) (Event, bool) {
+	for _, e := range c.Occurrences(t, t.Add(time.Nanosecond)) {
+		if !e.Free {
+			return e, true
+		}
+	}
+	return Event{}, false
+}
+
+// NextFree returns the start of the first free gap at least gap long at or
+// after from that starts before until. It reports false when there is none.
+func (c *Calendar) NextFree(from, until time.Time, gap time.Duration) (time.Time, bool) {
+	var busy []Event
+	for _, e := range c.Occurrences(from, until.Add(gap)) {
+		if !e.Free {
+			busy = append(busy, e)
+		}
+	}
+
+	t := from
+	for _, e := range busy {
+		if !t.Before(until) {
+			return time.Time{}, false
+		}
+		if !e.Start.After(t) {
+			// the event is on at t, the gap can only start after it
+			if e.End().After(t) {
+				t = e.End()
+			}
+			continue
+		}
+		if e.Start.Sub(t) >= gap {
+			return t, true
+		}
+		t = e.End()
+	}
+	if !t.Before(until) {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func (e Event) excluded(start time.Time) bool {
+	for _, ex := range e.exdates {
+		if ex.Equal(start) {
+			return true
+		}
+		// EXDATE of all-day events are dates, which may be in another location
+		if e.AllDay && sameDate(ex, start) {
+			return true
+		}
+	}
+	return false
+}
+
+func overlaps(e Event, from, to time.Time) bool {
+	if e.duration == 0 {
+		return !e.Start.Before(from) && e.Start.Before(to)
+	}
+	return e.Start.Before(to) && e.End().After(from)
+}
+
+func sameDate(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
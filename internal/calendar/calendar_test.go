@@ -0,0 +1,170 @@
+package calendar
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func day(d, hour, min int) time.Time {
+	return time.Date(2026, 10, d, hour, min, 0, 0, time.UTC)
+}
+
+func starts(events []Event) []time.Time {
+	var out []time.Time
+	for _, e := range events {
+		out = append(out, e.Start)
+	}
+	return out
+}
+
+func TestOccurrences(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    string
+		from, to time.Time
+		expected []time.Time
+	}{
+		{
+			"daily with count",
+			"DTSTART:20261012T090000Z\nDTEND:20261012T091500Z\nRRULE:FREQ=DAILY;COUNT=3",
+			day(1, 0, 0), day(31, 0, 0),
+			[]time.Time{day(12, 9, 0), day(13, 9, 0), day(14, 9, 0)},
+		},
+		{
+			"weekdays until",
+			"DTSTART:20261014T090000Z\nDTEND:20261014T091500Z\nRRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR;UNTIL=20261021T090000Z",
+			day(1, 0, 0), day(31, 0, 0),
+			[]time.Time{day(14, 9, 0), day(16, 9, 0), day(19, 9, 0), day(21, 9, 0)},
+		},
+		{
+			"every other week",
+			"DTSTART:20261006T130000Z\nDURATION:PT1H\nRRULE:FREQ=WEEKLY;INTERVAL=2",
+			day(1, 0, 0), day(31, 0, 0),
+			[]time.Time{day(6, 13, 0), day(20, 13, 0)},
+		},
+		{
+			"only the window",
+			"DTSTART:20250101T090000Z\nDTEND:20250101T100000Z\nRRULE:FREQ=DAILY",
+			day(16, 9, 30), day(17, 9, 0),
+			[]time.Time{day(16, 9, 0)},
+		},
+		{
+			"exdate",
+			"DTSTART:20261012T090000Z\nDTEND:20261012T091500Z\nRRULE:FREQ=DAILY;COUNT=3\nEXDATE:20261013T090000Z",
+			day(1, 0, 0), day(31, 0, 0),
+			[]time.Time{day(12, 9, 0), day(14, 9, 0)},
+		},
+		{
+			"monthly skips short months",
+			"DTSTART:20260831T090000Z\nDTEND:20260831T100000Z\nRRULE:FREQ=MONTHLY;COUNT=3",
+			time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+			[]time.Time{time.Date(2026, 8, 31, 9, 0, 0, 0, time.UTC), day(31, 9, 0), time.Date(2026, 12, 31, 9, 0, 0, 0, time.UTC)},
+		},
+		{
+			"yearly",
+			"DTSTART;VALUE=DATE:20201016\nTRANSP:OPAQUE\nRRULE:FREQ=YEARLY",
+			day(16, 12, 0), day(16, 13, 0),
+			[]time.Time{day(16, 0, 0)},
+		},
+		{
+			"unsupported rule keeps the first occurrence",
+			"DTSTART:20261012T090000Z\nDTEND:20261012T091500Z\nRRULE:FREQ=MONTHLY;BYDAY=2MO",
+			day(1, 0, 0), day(31, 0, 0),
+			[]time.Time{day(12, 9, 0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := parse(t, "BEGIN:VCALENDAR\nBEGIN:VEVENT\n"+tt.event+"\nEND:VEVENT\nEND:VCALENDAR\n")
+			got := starts(cal.Occurrences(tt.from, tt.to))
+			if !slices.EqualFunc(got, tt.expected, time.Time.Equal) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestOccurrencesKeepWallClockTime(t *testing.T) {
+	oslo, err := time.LoadLocation("Europe/Oslo")
+	if err != nil {
+		t.Skipf("No time zone data: %v", err)
+	}
+	cal := parse(t, "BEGIN:VCALENDAR\nBEGIN:VEVENT\n"+
+		"DTSTART;TZID=Europe/Oslo:20261023T100000\nDURATION:PT30M\nRRULE:FREQ=WEEKLY;COUNT=2\n"+
+		"END:VEVENT\nEND:VCALENDAR\n")
+
+	// summer time ends between the occurrences
+	got := starts(cal.Occurrences(day(1, 0, 0), time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC)))
+	expected := []time.Time{time.Date(2026, 10, 23, 10, 0, 0, 0, oslo), time.Date(2026, 10, 30, 10, 0, 0, 0, oslo)}
+	if !slices.EqualFunc(got, expected, time.Time.Equal) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestMovedOccurrence(t *testing.T) {
+	cal := parse(t, "BEGIN:VCALENDAR\n"+
+		"BEGIN:VEVENT\nUID:sync\nDTSTART:20261012T090000Z\nDURATION:PT30M\nRRULE:FREQ=DAILY;COUNT=3\nEND:VEVENT\n"+
+		"BEGIN:VEVENT\nUID:sync\nRECURRENCE-ID:20261013T090000Z\nDTSTART:20261013T150000Z\nDURATION:PT30M\nEND:VEVENT\n"+
+		"END:VCALENDAR\n")
+
+	got := starts(cal.Occurrences(day(13, 0, 0), day(14, 0, 0)))
+	expected := []time.Time{day(13, 15, 0)}
+	if !slices.EqualFunc(got, expected, time.Time.Equal) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestBusyAtAndNextFree(t *testing.T) {
+	cal := parse(t, "BEGIN:VCALENDAR\n"+
+		"BEGIN:VEVENT\nSUMMARY:Planning\nDTSTART:20261016T140000Z\nDTEND:20261016T150000Z\nEND:VEVENT\n"+
+		"BEGIN:VEVENT\nSUMMARY:Overlapping\nDTSTART:20261016T143000Z\nDTEND:20261016T151000Z\nEND:VEVENT\n"+
+		"BEGIN:VEVENT\nSUMMARY:One-on-one\nDTSTART:20261016T151500Z\nDTEND:20261016T160000Z\nEND:VEVENT\n"+
+		"BEGIN:VEVENT\nSUMMARY:Retro\nDTSTART:20261016T163000Z\nDTEND:20261016T173000Z\nEND:VEVENT\n"+
+		"BEGIN:VEVENT\nSUMMARY:Focus\nDTSTART:20261016T120000Z\nDTEND:20261016T180000Z\nTRANSP:TRANSPARENT\nEND:VEVENT\n"+
+		"BEGIN:VEVENT\nSUMMARY:Conference\nDTSTART;VALUE=DATE:20261016\nEND:VEVENT\n"+
+		"END:VCALENDAR\n")
+
+	if e, ok := cal.BusyAt(day(16, 14, 10)); !ok || e.Summary != "Planning" {
+		t.Errorf("Expected to be busy with Planning, got %q (busy: %v)", e.Summary, ok)
+	}
+	if e, ok := cal.BusyAt(day(16, 13, 0)); ok {
+		t.Errorf("Expected to be free during transparent and all-day events, got %q", e.Summary)
+	}
+	if _, ok := cal.BusyAt(day(16, 15, 0)); !ok {
+		t.Error("Expected overlapping event to keep it busy")
+	}
+	if _, ok := cal.BusyAt(day(16, 16, 0)); ok {
+		t.Error("Expected to be free when an event ends")
+	}
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		until    time.Time
+		gap      time.Duration
+		expected time.Time // zero for no gap
+	}{
+		{"free now", day(16, 13, 0), day(16, 18, 0), 5 * time.Minute, day(16, 13, 0)},
+		{"after overlapping events", day(16, 14, 10), day(16, 18, 0), 5 * time.Minute, day(16, 15, 10)},
+		{"gap too short", day(16, 14, 10), day(16, 18, 0), 10 * time.Minute, day(16, 16, 0)},
+		{"after the last event", day(16, 14, 10), day(16, 18, 0), 45 * time.Minute, day(16, 17, 30)},
+		{"no gap before until", day(16, 14, 10), day(16, 17, 0), 45 * time.Minute, time.Time{}},
+		{"gap must start before until", day(16, 16, 40), day(16, 17, 30), 5 * time.Minute, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := cal.NextFree(tt.from, tt.until, tt.gap)
+			if tt.expected.IsZero() {
+				if ok {
+					t.Errorf("Expected no free gap, got %v", got)
+				}
+				return
+			}
+			if !ok || !got.Equal(tt.expected) {
+				t.Errorf("Expected %v, got %v (found: %v)", tt.expected, got, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package calendar
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parse reads the events of an iCalendar (.ics) file. It understands the subset
+// calendar apps export for meetings: VEVENT with DTSTART, DTEND or DURATION,
+// all-day dates, TZID time zones, basic RRULEs, EXDATE and moved occurrences.
+// Floating times and zones Go doesn't know are read in loc.
+func Parse(r io.Reader, loc *time.Location) (*Calendar, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+
+	cal := &Calendar{}
+	var (
+		ev      *Event
+		raw     rawEvent
+		nesting []string
+	)
+	for i, line := range lines {
+		p, err := parseProperty(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		switch p.name {
+		case "BEGIN":
+			nesting = append(nesting, strings.ToUpper(p.value))
+			if strings.EqualFold(p.value, "VEVENT") {
+				ev, raw = &Event{}, rawEvent{}
+			}
+			continue
+		case "END":
+			if len(nesting) == 0 || nesting[len(nesting)-1] != strings.ToUpper(p.value) {
+				return nil, fmt.Errorf("line %d: unexpected END:%s", i+1, p.value)
+			}
+			nesting = nesting[:len(nesting)-1]
+			if strings.EqualFold(p.value, "VEVENT") {
+				if err := cal.add(ev, raw); err != nil {
+					return nil, fmt.Errorf("event ending on line %d: %w", i+1, err)
+				}
+				ev = nil
+			}
+			continue
+		}
+		// properties of time zones, alarms and the calendar itself aren't needed
+		if ev == nil || nesting[len(nesting)-1] != "VEVENT" {
+			continue
+		}
+		if err := raw.set(ev, p, loc); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+	}
+	if len(nesting) > 0 {
+		return nil, fmt.Errorf("missing END:%s", nesting[len(nesting)-1])
+	}
+	cal.applyOverrides()
+	return cal, nil
+}
+
+// Load parses the iCalendar file at path
+func Load(path string, loc *time.Location) (*Calendar, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open calendar: %w", err)
+	}
+	defer f.Close()
+	return Parse(f, loc)
+}
+
+// rawEvent holds the properties of an event that are only resolved once the whole
+// event has been read
+type rawEvent struct {
+	end          time.Time
+	duration     time.Duration
+	hasDuration  bool
+	transp       string
+	status       string
+	rrule        string
+	recurrenceID time.Time
+}
+
+func (raw *rawEvent) set(ev *Event, p property, loc *time.Location) error {
+	var err error
+	switch p.name {
+	case "UID":
+		ev.UID = p.value
+	case "SUMMARY":
+		ev.Summary = unescapeText(p.value)
+	case "DTSTART":
+		ev.Start, ev.AllDay, err = parseTime(p, loc)
+	case "DTEND":
+		raw.end, _, err = parseTime(p, loc)
+	case "DURATION":
+		raw.duration, err = parseDuration(p.value)
+		raw.hasDuration = true
+	case "TRANSP":
+		raw.transp = strings.ToUpper(p.value)
+	case "STATUS":
+		raw.status = strings.ToUpper(p.value)
+	case "RRULE":
+		raw.rrule = p.value
+	case "EXDATE":
+		for _, value := range strings.Split(p.value, ",") {
+			var t time.Time
+			t, _, err = parseTime(property{name: p.name, params: p.params, value: value}, loc)
+			if err != nil {
+				break
+			}
+			ev.exdates = append(ev.exdates, t)
+		}
+	case "RECURRENCE-ID":
+		raw.recurrenceID, _, err = parseTime(p, loc)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", p.name, err)
+	}
+	return nil
+}
+
+// add adds a fully read event to the calendar
+func (c *Calendar) add(ev *Event, raw rawEvent) error {
+	if ev.Start.IsZero() {
+		return errors.New("missing DTSTART")
+	}
+	if raw.status == "CANCELLED" {
+		return nil
+	}
+
+	switch {
+	case !raw.end.IsZero():
+		ev.duration = raw.end.Sub(ev.Start)
+	case raw.hasDuration:
+		ev.duration = raw.duration
+	case ev.AllDay:
+		ev.duration = 24 * time.Hour
+	}
+	if ev.duration < 0 {
+		return errors.New("event ends before it starts")
+	}
+
+	// all-day events are mostly birthdays and reminders, so they only block time
+	// when marked as busy
+	ev.Free = raw.transp == "TRANSPARENT" || ev.AllDay && raw.transp != "OPAQUE"
+
+	if raw.rrule != "" {
+		r, err := parseRule(raw.rrule, ev.Start.Location())
+		if err != nil {
+			// the first occurrence still counts, repeats it can't follow are left out
+			c.Unsupported = append(c.Unsupported, ev.Summary)
+		} else {
+			ev.rule = r
+		}
+	}
+
+	if !raw.recurrenceID.IsZero() {
+		c.overrides = append(c.overrides, override{uid: ev.UID, recurrenceID: raw.recurrenceID})
+	}
+	c.Events = append(c.Events, *ev)
+	return nil
+}
+
+// property is a content line: NAME;PARAM=VALUE:value
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+func parseProperty(line string) (property, error) {
+	colon := -1
+	inQuotes := false
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		}
+		if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon == -1 {
+		return property{}, fmt.Errorf("missing ':' in %q", line)
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	p := property{name: strings.ToUpper(parts[0]), value: line[colon+1:], params: make(map[string]string)}
+	if p.name == "" {
+		return property{}, fmt.Errorf("missing property name in %q", line)
+	}
+	for _, param := range parts[1:] {
+		key, value, ok := strings.Cut(param, "=")
+		if !ok {
+			return property{}, fmt.Errorf("invalid parameter %q", param)
+		}
+		p.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return p, nil
+}
+
+// unfold joins the folded lines of an iCalendar file, continuation lines starting
+// with a space or tab
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) == 0 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// parseTime parses a DATE or DATE-TIME value. Dates are all-day and start at
+// midnight in loc.
+func parseTime(p property, loc *time.Location) (t time.Time, allDay bool, err error) {
+	value := strings.TrimSpace(p.value)
+	if strings.EqualFold(p.params["VALUE"], "DATE") || len(value) == len("20060102") {
+		t, err = time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err = time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+	t, err = time.ParseInLocation("20060102T150405", value, zone(p.params["TZID"], loc))
+	return t, false, err
+}
+
+// zone returns the location of a TZID, falling back to loc for zones Go doesn't
+// know, like the Windows zone names some calendar apps write
+func zone(tzid string, loc *time.Location) *time.Location {
+	if tzid == "" {
+		return loc
+	}
+	if l, err := time.LoadLocation(strings.TrimPrefix(tzid, "/")); err == nil {
+		return l
+	}
+	return loc
+}
+
+// parseDuration parses an iCalendar duration like PT1H30M, P1D or P2W
+func parseDuration(value string) (time.Duration, error) {
+	s := value
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign, s = -1, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	s = s[1:]
+
+	units := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour}
+	var d time.Duration
+	number := ""
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			number += string(c)
+		case c == 'T':
+			if number != "" {
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+			units = map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+		default:
+			unit, ok := units[c]
+			if !ok || number == "" {
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+			n, _ := strconv.Atoi(number)
+			d += time.Duration(n) * unit
+			number = ""
+		}
+	}
+	if number != "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return sign * d, nil
+}
+
+// unescapeText undoes the escaping of TEXT values
+func unescapeText(s string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
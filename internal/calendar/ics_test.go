@@ -0,0 +1,173 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func parse(t *testing.T, ics string) *Calendar {
+	t.Helper()
+	cal, err := Parse(strings.NewReader(ics), time.UTC)
+	if err != nil {
+		t.Fatalf("Failed to parse calendar: %v", err)
+	}
+	return cal
+}
+
+func TestParseEvents(t *testing.T) {
+	oslo, err := time.LoadLocation("Europe/Oslo")
+	if err != nil {
+		t.Skipf("No time zone data: %v", err)
+	}
+
+	cal := parse(t, "\ufeffBEGIN:VCALENDAR\r\n"+
+		"VERSION:2.0\r\n"+
+		"BEGIN:VTIMEZONE\r\n"+
+		"TZID:Europe/Oslo\r\n"+
+		"BEGIN:STANDARD\r\n"+
+		"DTSTART:19701025T030000\r\n"+
+		"END:STANDARD\r\n"+
+		"END:VTIMEZONE\r\n"+
+		"BEGIN:VEVENT\r\n"+
+		"UID:standup\r\n"+
+		"SUMMARY:Stand-up\\, team A\r\n"+
+		"DTSTART;TZID=Europe/Oslo:20261016T140000\r\n"+
+		"DTEND;TZID=Europe/Oslo:20261016T143000\r\n"+
+		"BEGIN:VALARM\r\n"+
+		"TRIGGER:-PT15M\r\n"+
+		"END:VALARM\r\n"+
+		"END:VEVENT\r\n"+
+		"BEGIN:VEVENT\r\n"+
+		"UID:review\r\n"+
+		"SUMMARY:Quarterly review with a very long summary that\r\n"+
+		"  was folded\r\n"+
+		"DTSTART:20261016T120000Z\r\n"+
+		"DURATION:PT1H30M\r\n"+
+		"END:VEVENT\r\n"+
+		"BEGIN:VEVENT\r\n"+
+		"SUMMARY:Birthday\r\n"+
+		"DTSTART;VALUE=DATE:20261016\r\n"+
+		"END:VEVENT\r\n"+
+		"BEGIN:VEVENT\r\n"+
+		"SUMMARY:Focus time\r\n"+
+		"DTSTART:20261016T150000\r\n"+
+		"DTEND:20261016T160000\r\n"+
+		"TRANSP:TRANSPARENT\r\n"+
+		"END:VEVENT\r\n"+
+		"BEGIN:VEVENT\r\n"+
+		"SUMMARY:Cancelled\r\n"+
+		"DTSTART:20261016T150000\r\n"+
+		"STATUS:CANCELLED\r\n"+
+		"END:VEVENT\r\n"+
+		"END:VCALENDAR\r\n")
+
+	if len(cal.Events) != 4 {
+		t.Fatalf("Expected 4 events, got %d: %+v", len(cal.Events), cal.Events)
+	}
+
+	tests := []struct {
+		summary string
+		start   time.Time
+		end     time.Time
+		allDay  bool
+		free    bool
+	}{
+		{"Stand-up, team A", time.Date(2026, 10, 16, 14, 0, 0, 0, oslo), time.Date(2026, 10, 16, 14, 30, 0, 0, oslo), false, false},
+		{"Quarterly review with a very long summary that was folded", time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC), time.Date(2026, 10, 16, 13, 30, 0, 0, time.UTC), false, false},
+		{"Birthday", time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), true, true},
+		{"Focus time", time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC), time.Date(2026, 10, 16, 16, 0, 0, 0, time.UTC), false, true},
+	}
+	for i, tt := range tests {
+		e := cal.Events[i]
+		if e.Summary != tt.summary {
+			t.Errorf("Event %d: expected summary %q, got %q", i, tt.summary, e.Summary)
+		}
+		if !e.Start.Equal(tt.start) || !e.End().Equal(tt.end) {
+			t.Errorf("%s: expected %v to %v, got %v to %v", tt.summary, tt.start, tt.end, e.Start, e.End())
+		}
+		if e.AllDay != tt.allDay || e.Free != tt.free {
+			t.Errorf("%s: expected all-day %v and free %v, got %v and %v", tt.summary, tt.allDay, tt.free, e.AllDay, e.Free)
+		}
+	}
+}
+
+func TestParseZones(t *testing.T) {
+	local := time.FixedZone("local", 2*60*60)
+
+	cal, err := Parse(strings.NewReader("BEGIN:VCALENDAR\n"+
+		"BEGIN:VEVENT\nDTSTART:20261016T090000\nEND:VEVENT\n"+
+		"BEGIN:VEVENT\nDTSTART;TZID=W. Europe Standard Time:20261016T090000\nEND:VEVENT\n"+
+		"BEGIN:VEVENT\nDTSTART;VALUE=DATE:20261016\nTRANSP:OPAQUE\nEND:VEVENT\n"+
+		"END:VCALENDAR\n"), local)
+	if err != nil {
+		t.Fatalf("Failed to parse calendar: %v", err)
+	}
+
+	want := time.Date(2026, 10, 16, 9, 0, 0, 0, local)
+	if !cal.Events[0].Start.Equal(want) {
+		t.Errorf("Expected floating time in the local zone %v, got %v", want, cal.Events[0].Start)
+	}
+	if !cal.Events[1].Start.Equal(want) {
+		t.Errorf("Expected unknown zone to fall back to the local zone %v, got %v", want, cal.Events[1].Start)
+	}
+	allDay := cal.Events[2]
+	if !allDay.Start.Equal(time.Date(2026, 10, 16, 0, 0, 0, 0, local)) || allDay.Free {
+		t.Errorf("Expected busy all-day event starting at local midnight, got %v (free: %v)", allDay.Start, allDay.Free)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		ics  string
+	}{
+		{"missing colon", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART\nEND:VEVENT\nEND:VCALENDAR\n"},
+		{"missing start", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:x\nEND:VEVENT\nEND:VCALENDAR\n"},
+		{"invalid start", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:tomorrow\nEND:VEVENT\nEND:VCALENDAR\n"},
+		{"invalid duration", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20261016T090000Z\nDURATION:1H\nEND:VEVENT\nEND:VCALENDAR\n"},
+		{"ends before start", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20261016T090000Z\nDTEND:20261016T080000Z\nEND:VEVENT\nEND:VCALENDAR\n"},
+		{"unbalanced", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20261016T090000Z\nEND:VCALENDAR\n"},
+		{"unterminated", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20261016T090000Z\nEND:VEVENT\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(tt.ics), time.UTC); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"PT15M", 15 * time.Minute, false},
+		{"PT1H30M", 90 * time.Minute, false},
+		{"P1D", 24 * time.Hour, false},
+		{"P1DT2H", 26 * time.Hour, false},
+		{"P2W", 14 * 24 * time.Hour, false},
+		{"-PT5M", -5 * time.Minute, false},
+		{"PT", 0, true},
+		{"P1H", 0, true},
+		{"PT1D", 0, true},
+		{"PT5", 0, true},
+		{"15M", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			d, err := parseDuration(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if d != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, d)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package calendar
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is how often a recurring event repeats
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// maxOccurrences bounds the expansion of a rule, so a broken calendar can't keep
+// the reminder busy
+const maxOccurrences = 100000
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// rule is a recurrence rule: every Interval days, weeks, months or years, on the
+// ByDay weekdays of weekly rules, until Until or Count occurrences
+type rule struct {
+	Freq     Frequency
+	Interval int
+	Count    int       // 0 for no limit
+	Until    time.Time // zero for no limit, inclusive
+	ByDay    []time.Weekday
+}
+
+// parseRule parses an RRULE value. Parts beyond FREQ, INTERVAL, COUNT, UNTIL and the
+// weekdays of weekly rules are reported as unsupported.
+func parseRule(value string, loc *time.Location) (*rule, error) {
+	r := &rule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rule part %q", part)
+		}
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			switch f := Frequency(strings.ToUpper(val)); f {
+			case Daily, Weekly, Monthly, Yearly:
+				r.Freq = f
+			default:
+				return nil, fmt.Errorf("unsupported frequency %s", val)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid interval %q", val)
+			}
+			r.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid count %q", val)
+			}
+			r.Count = n
+		case "UNTIL":
+			until, _, err := parseTime(property{value: val, params: map[string]string{}}, loc)
+			if err != nil {
+				return nil, fmt.Errorf("invalid until %q", val)
+			}
+			r.Until = until
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				wd, ok := weekdays[strings.ToUpper(day)]
+				if !ok {
+					// e.g. 2TU, the second Tuesday of a month
+					return nil, fmt.Errorf("unsupported weekday %q", day)
+				}
+				r.ByDay = append(r.ByDay, wd)
+			}
+		case "WKST":
+			// only matters for weekly rules with an interval, which start weeks on Monday here
+		default:
+			return nil, fmt.Errorf("unsupported rule part %s", key)
+		}
+	}
+	if r.Freq == "" {
+		return nil, fmt.Errorf("rule without FREQ: %s", value)
+	}
+	if len(r.ByDay) > 0 && r.Freq != Weekly {
+		return nil, fmt.Errorf("BYDAY is only supported for weekly rules")
+	}
+	return r, nil
+}
+
+// starts calls fn with the start of every occurrence of a rule beginning at start,
+// in order, until fn returns false
+func (r *rule) starts(start time.Time, fn func(time.Time) bool) {
+	count := 0
+	emit := func(t time.Time) bool {
+		if !r.Until.IsZero() && t.After(r.Until) {
+			return false
+		}
+		if r.Count > 0 && count >= r.Count {
+			return false
+		}
+		count++
+		return fn(t)
+	}
+
+	y, m, d := start.Date()
+	hour, min, sec := start.Clock()
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, hour, min, sec, start.Nanosecond(), start.Location())
+	}
+
+	if r.Freq == Weekly && len(r.ByDay) > 0 {
+		// weeks start on Monday, as in RFC 5545
+		monday := d - (int(start.Weekday())+6)%7
+		for week := 0; week < maxOccurrences; week++ {
+			for offset := 0; offset < 7; offset++ {
+				t := at(y, m, monday+week*7*r.Interval+offset)
+				if t.Before(start) || !r.onDay(t.Weekday()) {
+					continue
+				}
+				if !emit(t) {
+					return
+				}
+			}
+		}
+		return
+	}
+
+	for i := 0; i < maxOccurrences; i++ {
+		n := i * r.Interval
+		var t time.Time
+		switch r.Freq {
+		case Daily:
+			t = at(y, m, d+n)
+		case Weekly:
+			t = at(y, m, d+7*n)
+		case Monthly:
+			t = at(y, m+time.Month(n), d)
+			// the 31st doesn't happen every month, months without the day are skipped
+			if t.Day() != d {
+				continue
+			}
+		case Yearly:
+			t = at(y+n, m, d)
+			if t.Day() != d {
+				continue
+			}
+		}
+		if !emit(t) {
+			return
+		}
+	}
+}
+
+func (r *rule) onDay(wd time.Weekday) bool {
+	for _, d := range r.ByDay {
+		if d == wd {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"path/filepath"
+)
+
+const (
+	pauseLogFile = "pause_log.json"
+	// MaxPauseLogEntries bounds how many pause events are kept
+	MaxPauseLogEntries = 200
+)
+
+// Pause log actions
+const (
+	PauseActionPause      = "pause"
+	PauseActionResume     = "resume"
+	PauseActionAutoResume = "auto-resume"
+	PauseActionPlan       = "plan"
+	PauseActionUnplan     = "unplan"
+)
+
+// PauseEvent records who paused or resumed reminders, when, and why
+type PauseEvent struct {
+	Timestamp int64  `json:"timestamp"`
+	Action    string `json:"action"`
+	User      string `json:"user,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	Until     int64  `json:"until,omitempty"`  // for pauses: same meaning as Config.PausedUntil, for planned pauses: the end
+	From      int64  `json:"from,omitempty"`   // for planned pauses: the start
+	Window    int    `json:"window,omitempty"` // for planned pauses: the ID
+}
+
+// PauseLog returns the recorded pause events, oldest first
+func (cm *ConfigManager) PauseLog() ([]PauseEvent, error) {
+	return readList[PauseEvent](cm.pauseLogPath(), "pause log")
+}
+
+// RecordPauseEvent appends an event to the pause log, dropping the oldest events when it's full
+func (cm *ConfigManager) RecordPauseEvent(event PauseEvent) error {
+	return appendCapped(cm.pauseLogPath(), "pause log", event, MaxPauseLogEntries)
+}
+
+func (cm *ConfigManager) pauseLogPath() string {
+	return filepath.Join(cm.configDir, pauseLogFile)
+}
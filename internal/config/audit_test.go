@@ -0,0 +1,54 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestPauseLog(t *testing.T) {
+	cm := &ConfigManager{
+		configDir:  t.TempDir(),
+		configFile: "test_config.json",
+	}
+
+	events, err := cm.PauseLog()
+	if err != nil {
+		t.Fatalf("Failed to read empty pause log: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected no events, got %d", len(events))
+	}
+
+	if err := cm.RecordPauseEvent(PauseEvent{Timestamp: 100, Action: PauseActionPause, User: "tobias", Reason: "vacation", Until: 200}); err != nil {
+		t.Fatalf("Failed to record event: %v", err)
+	}
+	if err := cm.RecordPauseEvent(PauseEvent{Timestamp: 200, Action: PauseActionAutoResume}); err != nil {
+		t.Fatalf("Failed to record event: %v", err)
+	}
+
+	events, err = cm.PauseLog()
+	if err != nil {
+		t.Fatalf("Failed to read pause log: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if events[0].Reason != "vacation" || events[0].User != "tobias" || events[0].Until != 200 {
+		t.Errorf("Unexpected first event: %+v", events[0])
+	}
+	if events[1].Action != PauseActionAutoResume {
+		t.Errorf("Expected auto-resume, got %s", events[1].Action)
+	}
+
+	for i := 0; i < MaxPauseLogEntries; i++ {
+		if err := cm.RecordPauseEvent(PauseEvent{Timestamp: int64(1000 + i), Action: PauseActionResume}); err != nil {
+			t.Fatalf("Failed to record event: %v", err)
+		}
+	}
+	events, _ = cm.PauseLog()
+	if len(events) != MaxPauseLogEntries {
+		t.Errorf("Expected %d events, got %d", MaxPauseLogEntries, len(events))
+	}
+	if events[0].Timestamp != 1000 {
+		t.Errorf("Expected the oldest events to be dropped, first is %d", events[0].Timestamp)
+	}
+}
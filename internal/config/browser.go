@@ -0,0 +1,19 @@
+package config
+
+import (
+	"errors"
+	"strings"
+)
+
+// BrowserSettings controls which browser opens the order page
+type BrowserSettings struct {
+	Command string   `json:"command,omitempty"` // browser executable, the system browser when empty
+	Args    []string `json:"args,omitempty"`    // arguments before the URL, e.g. a profile: ["-P", "work"]
+}
+
+func (b BrowserSettings) validate() error {
+	if strings.TrimSpace(b.Command) == "" && len(b.Args) > 0 {
+		return errors.New("browser arguments need a browser command")
+	}
+	return nil
+}
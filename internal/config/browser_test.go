@@ -0,0 +1,25 @@
+package config
+
+import "testing"
+
+func TestValidateBrowserSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings BrowserSettings
+		wantErr  bool
+	}{
+		{"system browser", BrowserSettings{}, false},
+		{"command", BrowserSettings{Command: "firefox"}, false},
+		{"profile", BrowserSettings{Command: "firefox", Args: []string{"-P", "work"}}, false},
+		{"arguments without command", BrowserSettings{Args: []string{"-P", "work"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sultengutt/internal/calendar"
+	"time"
+)
+
+const (
+	// DefaultCalendarGap is the free time needed to show a reminder after a meeting
+	DefaultCalendarGap = 5 * time.Minute
+	// DefaultDeferLimit is how long a reminder waits for meetings to end when no
+	// order cutoff is set
+	DefaultDeferLimit = 2 * time.Hour
+)
+
+// CalendarSettings points to a local calendar file. Reminders falling into one of
+// its meetings are shown in the next free gap instead.
+type CalendarSettings struct {
+	Path          string `json:"path,omitempty"`            // .ics file, no calendar when empty
+	MinGapMinutes int    `json:"min_gap_minutes,omitempty"` // free time needed to show the reminder, 0 for the default
+}
+
+// Gap returns the free time needed to show the reminder
+func (c CalendarSettings) Gap() time.Duration {
+	if c.MinGapMinutes == 0 {
+		return DefaultCalendarGap
+	}
+	return time.Duration(c.MinGapMinutes) * time.Minute
+}
+
+func (c CalendarSettings) validate() error {
+	if c.Path != "" && !filepath.IsAbs(c.Path) {
+		return fmt.Errorf("calendar path must be absolute: %s", c.Path)
+	}
+	if c.MinGapMinutes < 0 || c.MinGapMinutes >= 24*60 {
+		return fmt.Errorf("invalid calendar gap: %d minutes (must be between 1 and 1439)", c.MinGapMinutes)
+	}
+	return nil
+}
+
+// BusyCalendar loads the configured calendar in the local time zone, nil when there
+// is none. It is read on every reminder, so changes to the file are picked up.
+func (c *Config) BusyCalendar() (*calendar.Calendar, error) {
+	if c.Calendar.Path == "" {
+		return nil, nil
+	}
+	return calendar.Load(c.Calendar.Path, time.Local)
+}
+
+// Cutoff returns the order cutoff on the day of t, the latest time worth ordering
+// at, and whether one is set. A cutoff that isn't after the reminder time, e.g.
+// after the reminder was moved later, is ignored.
+func (c *Config) Cutoff(t time.Time) (time.Time, bool) {
+	if c.OrderCutoff == "" || c.CheckOrderCutoff() != nil {
+		return time.Time{}, false
+	}
+	cutoff, err := time.Parse("15:04", c.OrderCutoff)
+	if err != nil {
+		return time.Time{}, false
+	}
+	y, m, d := t.Date()
+	return time.Date(y, m, d, cutoff.Hour(), cutoff.Minute(), 0, 0, t.Location()), true
+}
+
+func validateOrderCutoff(cutoff string) error {
+	if cutoff != "" && !regexp.MustCompile(Time24hRegex).MatchString(cutoff) {
+		return fmt.Errorf("invalid order cutoff: %s", cutoff)
+	}
+	return nil
+}
+
+// CheckOrderCutoff checks that the order cutoff, if any, is after the reminder time
+func (c *Config) CheckOrderCutoff() error {
+	return checkOrderCutoff(c.OrderCutoff, c.InstallOptions.Hour)
+}
+
+func checkOrderCutoff(cutoff, hour string) error {
+	at, err := time.Parse("15:04", cutoff)
+	if err != nil {
+		return nil
+	}
+	reminder, err := time.Parse("15:04", hour)
+	if err == nil && !at.After(reminder) {
+		return fmt.Errorf("order cutoff %s must be after the reminder time %s", cutoff, hour)
+	}
+	return nil
+}
+
+// SetCalendar sets the calendar whose meetings reminders wait for
+func (c *Config) SetCalendar(settings CalendarSettings) error {
+	if err := settings.validate(); err != nil {
+		return err
+	}
+	c.Calendar = settings
+	return nil
+}
+
+// SetOrderCutoff sets the latest time worth ordering at, HH:MM or empty for none
+func (c *Config) SetOrderCutoff(cutoff string) error {
+	if err := validateOrderCutoff(cutoff); err != nil {
+		return err
+	}
+	if err := checkOrderCutoff(cutoff, c.InstallOptions.Hour); err != nil {
+		return err
+	}
+	c.OrderCutoff = cutoff
+	return nil
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestValidateCalendarSettings(t *testing.T) {
+	path := filepath.Join(os.TempDir(), "work.ics")
+	tests := []struct {
+		name     string
+		settings CalendarSettings
+		wantErr  bool
+	}{
+		{"no calendar", CalendarSettings{}, false},
+		{"calendar", CalendarSettings{Path: path, MinGapMinutes: 10}, false},
+		{"relative path", CalendarSettings{Path: "work.ics"}, true},
+		{"negative gap", CalendarSettings{Path: path, MinGapMinutes: -5}, true},
+		{"gap of a day", CalendarSettings{Path: path, MinGapMinutes: 24 * 60}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCalendarGap(t *testing.T) {
+	if gap := (CalendarSettings{}).Gap(); gap != DefaultCalendarGap {
+		t.Errorf("Expected default gap %v, got %v", DefaultCalendarGap, gap)
+	}
+	if gap := (CalendarSettings{MinGapMinutes: 15}).Gap(); gap != 15*time.Minute {
+		t.Errorf("Expected 15m, got %v", gap)
+	}
+}
+
+func TestBusyCalendar(t *testing.T) {
+	cfg := &Config{}
+	if cal, err := cfg.BusyCalendar(); cal != nil || err != nil {
+		t.Errorf("Expected no calendar, got %v (error: %v)", cal, err)
+	}
+
+	cfg.Calendar.Path = filepath.Join(t.TempDir(), "work.ics")
+	if _, err := cfg.BusyCalendar(); err == nil {
+		t.Error("Expected an error for a missing calendar file")
+	}
+
+	ics := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Planning\nDTSTART:20261016T140000Z\nDTEND:20261016T150000Z\nEND:VEVENT\nEND:VCALENDAR\n"
+	if err := os.WriteFile(cfg.Calendar.Path, []byte(ics), 0644); err != nil {
+		t.Fatalf("Failed to write calendar: %v", err)
+	}
+	cal, err := cfg.BusyCalendar()
+	if err != nil {
+		t.Fatalf("Failed to load calendar: %v", err)
+	}
+	if len(cal.Events) != 1 || cal.Events[0].Summary != "Planning" {
+		t.Errorf("Expected the Planning event, got %+v", cal.Events)
+	}
+}
+
+func TestOrderCutoff(t *testing.T) {
+	loc := time.FixedZone("local", 2*60*60)
+	cfg := &Config{}
+	if _, ok := cfg.Cutoff(time.Now()); ok {
+		t.Error("Expected no cutoff")
+	}
+
+	cfg.OrderCutoff = "15:30"
+	cutoff, ok := cfg.Cutoff(time.Date(2026, 10, 16, 14, 0, 0, 0, loc))
+	if !ok || !cutoff.Equal(time.Date(2026, 10, 16, 15, 30, 0, 0, loc)) {
+		t.Errorf("Expected 15:30 on the same day, got %v (set: %v)", cutoff, ok)
+	}
+
+	cfg.InstallOptions.Hour = "15:45"
+	if _, ok := cfg.Cutoff(time.Now()); ok {
+		t.Error("Expected a cutoff before the reminder time to be ignored")
+	}
+
+	tests := []struct {
+		cutoff  string
+		wantErr bool
+	}{
+		{"", false},
+		{"15:30", false},
+		{"9:45", true}, // before the reminder
+		{"14:30", true},
+		{"25:00", true},
+		{"3pm", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cutoff, func(t *testing.T) {
+			cfg := &Config{InstallOptions: InstallOptions{Hour: "14:30"}}
+			err := cfg.SetOrderCutoff(tt.cutoff)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetOrderCutoff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && cfg.OrderCutoff != "" {
+				t.Errorf("Expected an invalid cutoff not to be set, got %q", cfg.OrderCutoff)
+			}
+		})
+	}
+}
@@ -4,11 +4,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"slices"
+	"time"
 )
 
 const (
@@ -20,12 +20,26 @@ var validDays = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday",
 type InstallOptions struct {
 	Days     []string `json:"days"`
 	Hour     string   `json:"hour"`
-	SiteLink string   `json:"sitelink"`
+	SiteLink string   `json:"sitelink,omitempty"` // order URL of older configs, migrated to Vendors
 }
 
 type Config struct {
-	InstallOptions InstallOptions `json:"install_options"`
-	PausedUntil    int64          `json:"paused_until"` // -1: not paused, 0: paused indefinitely, >0: unix timestamp
+	InstallOptions InstallOptions   `json:"install_options"`
+	PausedUntil    int64            `json:"paused_until"` // -1: not paused, 0: paused indefinitely, >0: unix timestamp
+	PauseReason    string           `json:"pause_reason,omitempty"`
+	PauseWindows   []PauseWindow    `json:"pause_windows,omitempty"`
+	Holidays       HolidaySettings  `json:"holidays"`
+	Skips          []string         `json:"skips,omitempty"` // dates (YYYY-MM-DD) of reminders to skip
+	Notifiers      NotifierSettings `json:"notifiers"`
+	SnoozeMinutes  []int            `json:"snooze_minutes"` // snooze durations offered in the reminder, null for the defaults
+	Refire         RefireSettings   `json:"refire"`
+	Popup          PopupSettings    `json:"popup"`
+	Browser        BrowserSettings  `json:"browser"`
+	Vendors        []Vendor         `json:"vendors,omitempty"`
+	OrderCutoff    string           `json:"order_cutoff,omitempty"` // HH:MM, the latest time worth ordering at
+	Calendar       CalendarSettings `json:"calendar"`
+	Mantras        MantraSettings   `json:"mantras"`
+	State          State            `json:"-"` // saved in its own file, see State
 
 	configPath     string
 	isFreshInstall bool
@@ -34,6 +48,7 @@ type Config struct {
 type ConfigManager struct {
 	configDir  string
 	configFile string
+	command    string // command causing the current saves, recorded in history
 }
 
 func NewConfigManager() (*ConfigManager, error) {
@@ -66,6 +81,7 @@ func (cm *ConfigManager) Load() (*Config, error) {
 		if os.IsNotExist(err) {
 			cfg := &Config{
 				PausedUntil:    -1, // not paused by default
+				Holidays:       HolidaySettings{Regions: slices.Clone(DefaultHolidayRegions)},
 				configPath:     configPath,
 				isFreshInstall: true,
 			}
@@ -81,6 +97,10 @@ func (cm *ConfigManager) Load() (*Config, error) {
 
 	cfg.configPath = configPath
 	cfg.isFreshInstall = isFreshInstall
+	cfg.migrateSiteLink()
+	if err := cm.loadState(&cfg, data); err != nil {
+		return nil, err
+	}
 
 	if err := cfg.validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
@@ -99,18 +119,39 @@ func (cm *ConfigManager) Save(cfg *Config) error {
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
-	// atomic saving
-	tempPath := configPath + ".tmp"
+	if err := writeFileAtomic(configPath, data); err != nil {
+		return fmt.Errorf("failed to save config file: %w", err)
+	}
+	return cm.saveState(cfg.State)
+}
+
+// writeFileAtomic writes data to a temporary file first and renames it to path, so
+// path is never left half written
+func writeFileAtomic(path string, data []byte) error {
+	tempPath := path + ".tmp"
 	if err := os.WriteFile(tempPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write temp config file: %w", err)
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath)
+		return err
 	}
+	return nil
+}
 
-	if err := os.Rename(tempPath, configPath); err != nil {
-		err := os.Remove(tempPath)
-		if err != nil {
-			return err
-		}
-		return fmt.Errorf("failed to save config file: %w", err)
+// SaveWithHistory saves the config and records it in the history, for changes made by
+// the user. Saves of runtime state, e.g. by 'sultengutt execute', use Save so they don't
+// push the user's changes out of the history.
+func (cm *ConfigManager) SaveWithHistory(cfg *Config) error {
+	if err := cm.Save(cfg); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := cm.appendHistory(data); err != nil {
+		return fmt.Errorf("failed to record config history: %w", err)
 	}
 	return nil
 }
@@ -141,6 +182,7 @@ func (c *Config) SetPausedUntil(timestamp int64) {
 
 func (c *Config) Resume() {
 	c.PausedUntil = -1
+	c.PauseReason = ""
 }
 
 func (c *Config) Path() string {
@@ -164,12 +206,51 @@ func (c *Config) validate() error {
 	if !pattern.MatchString(c.InstallOptions.Hour) {
 		return errors.New("invalid hour specified")
 	}
-	if c.InstallOptions.SiteLink == "" {
+	if len(c.OrderVendors()) == 0 {
 		return errors.New("no site link specified")
 	}
-	_, err := url.Parse(c.InstallOptions.SiteLink)
-	if err != nil {
-		return errors.New("invalid URL format: " + c.InstallOptions.SiteLink)
+	if c.InstallOptions.SiteLink != "" {
+		if err := ValidateOrderURL(c.InstallOptions.SiteLink); err != nil {
+			return fmt.Errorf("invalid site link: %w", err)
+		}
+	}
+	if err := validateVendors(c.Vendors); err != nil {
+		return fmt.Errorf("invalid vendors: %w", err)
+	}
+	for _, w := range c.PauseWindows {
+		if w.To <= w.From {
+			return fmt.Errorf("invalid planned pause %d: end is before start", w.ID)
+		}
+	}
+	for _, date := range c.Skips {
+		if _, err := time.Parse(skipDateFormat, date); err != nil {
+			return fmt.Errorf("invalid skipped date: %s", date)
+		}
+	}
+	if _, err := c.HolidayCalendar(); err != nil {
+		return fmt.Errorf("invalid holidays: %w", err)
+	}
+	if err := validateSnoozeMinutes(c.SnoozeMinutes); err != nil {
+		return err
+	}
+	if err := c.Notifiers.validate(); err != nil {
+		return fmt.Errorf("invalid notifiers: %w", err)
+	}
+	if err := c.Popup.validate(); err != nil {
+		return fmt.Errorf("invalid popup settings: %w", err)
+	}
+	if err := c.Browser.validate(); err != nil {
+		return fmt.Errorf("invalid browser: %w", err)
+	}
+	// a cutoff the reminder time has moved past is only ignored, see Cutoff
+	if err := validateOrderCutoff(c.OrderCutoff); err != nil {
+		return err
+	}
+	if err := c.Calendar.validate(); err != nil {
+		return fmt.Errorf("invalid calendar: %w", err)
+	}
+	if err := c.Refire.validate(); err != nil {
+		return err
 	}
 	return nil
 }
@@ -170,6 +170,31 @@ func TestConfigValidation(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			// the reminder was moved past the cutoff, the cutoff is ignored instead
+			name: "order cutoff before the hour",
+			config: Config{
+				InstallOptions: InstallOptions{
+					Days:     []string{"Monday"},
+					Hour:     "16:00",
+					SiteLink: "https://example.com",
+				},
+				OrderCutoff: "15:30",
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid order cutoff",
+			config: Config{
+				InstallOptions: InstallOptions{
+					Days:     []string{"Monday"},
+					Hour:     "14:30",
+					SiteLink: "https://example.com",
+				},
+				OrderCutoff: "3pm",
+			},
+			expectError: true,
+		},
 		{
 			name: "empty days",
 			config: Config{
@@ -225,6 +250,18 @@ func TestConfigValidation(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "invalid pause window",
+			config: Config{
+				InstallOptions: InstallOptions{
+					Days:     []string{"Monday"},
+					Hour:     "14:30",
+					SiteLink: "https://example.com",
+				},
+				PauseWindows: []PauseWindow{{ID: 1, From: 200, To: 100}},
+			},
+			expectError: true,
+		},
 		{
 			name: "invalid URL",
 			config: Config{
@@ -236,6 +273,28 @@ func TestConfigValidation(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "URL template",
+			config: Config{
+				InstallOptions: InstallOptions{
+					Days:     []string{"Monday"},
+					Hour:     "14:30",
+					SiteLink: "https://canteen.example.com/menu?date={date}&team={env:TEAM_CODE}",
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "malformed URL template",
+			config: Config{
+				InstallOptions: InstallOptions{
+					Days:     []string{"Monday"},
+					Hour:     "14:30",
+					SiteLink: "https://canteen.example.com/menu?date={date",
+				},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	historyFile = "history.json"
+	// MaxHistoryEntries bounds how many config versions are kept around for undo
+	MaxHistoryEntries = 20
+)
+
+// HistoryEntry is a saved version of the config, along with when it was saved and by which command
+type HistoryEntry struct {
+	Timestamp int64           `json:"timestamp"`
+	Command   string          `json:"command"`
+	Config    json.RawMessage `json:"config"`
+}
+
+// SetCommand records which command is responsible for the next saves, so history can show it
+func (cm *ConfigManager) SetCommand(command string) {
+	cm.command = command
+}
+
+// History returns the saved config versions, oldest first
+func (cm *ConfigManager) History() ([]HistoryEntry, error) {
+	return readList[HistoryEntry](cm.historyPath(), "history file")
+}
+
+// Undo restores the config version saved `steps` saves ago into cfg and saves it
+func (cm *ConfigManager) Undo(cfg *Config, steps int) error {
+	if cfg == nil {
+		return errors.New("config is nil")
+	}
+	if steps < 1 {
+		return errors.New("number of steps must be at least 1")
+	}
+
+	entries, err := cm.History()
+	if err != nil {
+		return err
+	}
+	if steps >= len(entries) {
+		return fmt.Errorf("cannot undo %d change(s), only %d earlier version(s) in history", steps, max(len(entries)-1, 0))
+	}
+
+	entry := entries[len(entries)-1-steps]
+	var restored Config
+	if err := json.Unmarshal(entry.Config, &restored); err != nil {
+		return fmt.Errorf("failed to parse config from history: %w", err)
+	}
+	if err := restored.validate(); err != nil {
+		return fmt.Errorf("config in history is invalid: %w", err)
+	}
+
+	restored.configPath = cfg.configPath
+	restored.isFreshInstall = cfg.isFreshInstall
+	restored.State = cfg.State // the history only has the user's settings
+	*cfg = restored
+
+	return cm.SaveWithHistory(cfg)
+}
+
+// appendHistory adds a new version to the history ring, dropping the oldest versions
+// when it's full. Saves that don't change anything are not recorded.
+func (cm *ConfigManager) appendHistory(data []byte) error {
+	entries, err := cm.History()
+	if err != nil {
+		return err
+	}
+
+	if len(entries) > 0 {
+		last := entries[len(entries)-1].Config
+		var a, b bytes.Buffer
+		if json.Compact(&a, last) == nil && json.Compact(&b, data) == nil && bytes.Equal(a.Bytes(), b.Bytes()) {
+			return nil
+		}
+	}
+
+	return appendCapped(cm.historyPath(), "history file", HistoryEntry{
+		Timestamp: time.Now().Unix(),
+		Command:   cm.command,
+		Config:    json.RawMessage(data),
+	}, MaxHistoryEntries)
+}
+
+func (cm *ConfigManager) historyPath() string {
+	return filepath.Join(cm.configDir, historyFile)
+}
+
+// DiffVersions returns the changed fields between two config versions as
+// "- key: old" / "+ key: new" lines, with nested keys joined by dots
+func DiffVersions(before, after json.RawMessage) ([]string, error) {
+	oldFields := map[string]string{}
+	newFields := map[string]string{}
+
+	if len(before) > 0 {
+		var v interface{}
+		if err := json.Unmarshal(before, &v); err != nil {
+			return nil, fmt.Errorf("failed to parse config version: %w", err)
+		}
+		flatten("", v, oldFields)
+	}
+	var v interface{}
+	if err := json.Unmarshal(after, &v); err != nil {
+		return nil, fmt.Errorf("failed to parse config version: %w", err)
+	}
+	flatten("", v, newFields)
+
+	keys := make([]string, 0, len(oldFields)+len(newFields))
+	for k := range oldFields {
+		keys = append(keys, k)
+	}
+	for k := range newFields {
+		if _, ok := oldFields[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var lines []string
+	for _, k := range keys {
+		oldVal, hadOld := oldFields[k]
+		newVal, hasNew := newFields[k]
+		if hadOld && hasNew && oldVal == newVal {
+			continue
+		}
+		if hadOld {
+			lines = append(lines, "- "+k+": "+oldVal)
+		}
+		if hasNew {
+			lines = append(lines, "+ "+k+": "+newVal)
+		}
+	}
+	return lines, nil
+}
+
+// flatten turns nested JSON objects into dotted keys. Arrays are kept as a single value
+// so a changed list of days shows up as one line.
+func flatten(prefix string, v interface{}, out map[string]string) {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		data, _ := json.Marshal(v)
+		out[prefix] = string(data)
+		return
+	}
+	for k, child := range obj {
+		key := k
+		if prefix != "" {
+			key = strings.Join([]string{prefix, k}, ".")
+		}
+		flatten(key, child, out)
+	}
+}
@@ -0,0 +1,213 @@
+package config
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func newTestConfig(hour string) *Config {
+	return &Config{
+		InstallOptions: InstallOptions{
+			Days:     []string{"Monday", "Friday"},
+			Hour:     hour,
+			SiteLink: "https://example.com",
+		},
+		PausedUntil: -1,
+	}
+}
+
+func TestSaveRecordsHistory(t *testing.T) {
+	cm := &ConfigManager{
+		configDir:  t.TempDir(),
+		configFile: "test_config.json",
+	}
+
+	cm.SetCommand("sultengutt install")
+	cfg := newTestConfig("14:30")
+	if err := cm.SaveWithHistory(cfg); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+
+	// Saving the same config again should not add a new version
+	if err := cm.SaveWithHistory(cfg); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+
+	cm.SetCommand("sultengutt pause 1 day")
+	cfg.PausedUntil = 12345
+	if err := cm.SaveWithHistory(cfg); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+
+	entries, err := cm.History()
+	if err != nil {
+		t.Fatalf("Failed to read history: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 history entries, got %d", len(entries))
+	}
+	if entries[0].Command != "sultengutt install" {
+		t.Errorf("Expected first command 'sultengutt install', got '%s'", entries[0].Command)
+	}
+	if entries[1].Command != "sultengutt pause 1 day" {
+		t.Errorf("Expected second command 'sultengutt pause 1 day', got '%s'", entries[1].Command)
+	}
+	if entries[1].Timestamp <= 0 {
+		t.Errorf("Expected positive timestamp, got %d", entries[1].Timestamp)
+	}
+}
+
+func TestSaveSkipsHistory(t *testing.T) {
+	cm := &ConfigManager{
+		configDir:  t.TempDir(),
+		configFile: "test_config.json",
+	}
+
+	cfg := newTestConfig("14:30")
+	if err := cm.SaveWithHistory(cfg); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+	// runtime saves, e.g. an automatic resume, don't count as a change to undo
+	cfg.PausedUntil = 12345
+	if err := cm.Save(cfg); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+
+	entries, err := cm.History()
+	if err != nil {
+		t.Fatalf("Failed to read history: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 history entry, got %d", len(entries))
+	}
+}
+
+func TestHistoryIsBounded(t *testing.T) {
+	cm := &ConfigManager{
+		configDir:  t.TempDir(),
+		configFile: "test_config.json",
+	}
+
+	cfg := newTestConfig("14:30")
+	for i := 0; i < MaxHistoryEntries+5; i++ {
+		cfg.PausedUntil = int64(i + 1)
+		if err := cm.SaveWithHistory(cfg); err != nil {
+			t.Fatalf("Failed to save config: %v", err)
+		}
+	}
+
+	entries, err := cm.History()
+	if err != nil {
+		t.Fatalf("Failed to read history: %v", err)
+	}
+	if len(entries) != MaxHistoryEntries {
+		t.Fatalf("Expected %d history entries, got %d", MaxHistoryEntries, len(entries))
+	}
+
+	var oldest Config
+	if err := json.Unmarshal(entries[0].Config, &oldest); err != nil {
+		t.Fatalf("Failed to parse oldest entry: %v", err)
+	}
+	if oldest.PausedUntil != 6 {
+		t.Errorf("Expected oldest entry to be version 6, got %d", oldest.PausedUntil)
+	}
+}
+
+func TestUndo(t *testing.T) {
+	cm := &ConfigManager{
+		configDir:  t.TempDir(),
+		configFile: "test_config.json",
+	}
+
+	cfg := newTestConfig("14:30")
+	for _, hour := range []string{"15:00", "16:00"} {
+		if err := cm.SaveWithHistory(cfg); err != nil {
+			t.Fatalf("Failed to save config: %v", err)
+		}
+		cfg.InstallOptions.Hour = hour
+	}
+	if err := cm.SaveWithHistory(cfg); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+
+	t.Run("undo two steps", func(t *testing.T) {
+		cm.SetCommand("sultengutt config undo 2")
+		if err := cm.Undo(cfg, 2); err != nil {
+			t.Fatalf("Failed to undo: %v", err)
+		}
+		if cfg.InstallOptions.Hour != "14:30" {
+			t.Errorf("Expected hour '14:30' after undo, got '%s'", cfg.InstallOptions.Hour)
+		}
+
+		loaded, err := cm.Load()
+		if err != nil {
+			t.Fatalf("Failed to load config: %v", err)
+		}
+		if loaded.InstallOptions.Hour != "14:30" {
+			t.Errorf("Expected saved hour '14:30' after undo, got '%s'", loaded.InstallOptions.Hour)
+		}
+
+		entries, _ := cm.History()
+		if last := entries[len(entries)-1]; last.Command != "sultengutt config undo 2" {
+			t.Errorf("Expected undo to be recorded in history, got '%s'", last.Command)
+		}
+	})
+
+	t.Run("undo undo", func(t *testing.T) {
+		if err := cm.Undo(cfg, 1); err != nil {
+			t.Fatalf("Failed to undo: %v", err)
+		}
+		if cfg.InstallOptions.Hour != "16:00" {
+			t.Errorf("Expected hour '16:00' after undoing the undo, got '%s'", cfg.InstallOptions.Hour)
+		}
+	})
+
+	t.Run("too many steps", func(t *testing.T) {
+		if err := cm.Undo(cfg, 50); err == nil {
+			t.Error("Expected error when undoing past the history, but got none")
+		}
+	})
+
+	t.Run("zero steps", func(t *testing.T) {
+		if err := cm.Undo(cfg, 0); err == nil {
+			t.Error("Expected error for zero steps, but got none")
+		}
+	})
+}
+
+func TestDiffVersions(t *testing.T) {
+	before := json.RawMessage(`{"install_options":{"days":["Monday"],"hour":"14:30","sitelink":"https://example.com"},"paused_until":-1}`)
+	after := json.RawMessage(`{"install_options":{"days":["Monday","Friday"],"hour":"14:30","sitelink":"https://example.com"},"paused_until":0}`)
+
+	lines, err := DiffVersions(before, after)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		`- install_options.days: ["Monday"]`,
+		`+ install_options.days: ["Monday","Friday"]`,
+		`- paused_until: -1`,
+		`+ paused_until: 0`,
+	}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected diff:\n%s\nexpected:\n%s", strings.Join(lines, "\n"), strings.Join(expected, "\n"))
+	}
+
+	t.Run("first version", func(t *testing.T) {
+		lines, err := DiffVersions(nil, before)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(lines) != 4 {
+			t.Errorf("Expected every field to be added, got %v", lines)
+		}
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		if _, err := DiffVersions(before, json.RawMessage("nope")); err == nil {
+			t.Error("Expected error for invalid JSON, but got none")
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"sultengutt/internal/holidays"
+	"time"
+)
+
+// DefaultHolidayRegions are the regions of new configs. Configs from before holidays
+// were supported keep public holidays disabled, so upgrading doesn't start skipping
+// reminders.
+var DefaultHolidayRegions = []string{"NO"}
+
+// HolidaySettings controls which days reminders are skipped on.
+// Without regions public holidays are disabled.
+type HolidaySettings struct {
+	Regions []string        `json:"regions"`
+	Custom  []CustomHoliday `json:"custom,omitempty"`
+}
+
+// CustomHoliday is a user-added day off, YYYY-MM-DD for a single date or MM-DD for every year
+type CustomHoliday struct {
+	Date string `json:"date"`
+	Name string `json:"name,omitempty"`
+}
+
+// HolidayRegions returns the configured regions
+func (c *Config) HolidayRegions() []string {
+	return c.Holidays.Regions
+}
+
+// HolidayCalendar builds the holiday calendar from the config in the local time zone
+func (c *Config) HolidayCalendar() (*holidays.Calendar, error) {
+	cal, err := holidays.NewCalendar(c.HolidayRegions(), time.Local)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range c.Holidays.Custom {
+		if err := cal.AddCustom(h.Date, h.Name); err != nil {
+			return nil, err
+		}
+	}
+	return cal, nil
+}
+
+// AddCustomHoliday adds a user-defined day off, replacing the name if the date already exists
+func (c *Config) AddCustomHoliday(date, name string) error {
+	if err := holidays.ValidateCustomDate(date); err != nil {
+		return err
+	}
+	for i, h := range c.Holidays.Custom {
+		if h.Date == date {
+			c.Holidays.Custom[i].Name = name
+			return nil
+		}
+	}
+	c.Holidays.Custom = append(c.Holidays.Custom, CustomHoliday{Date: date, Name: name})
+	return nil
+}
+
+// RemoveCustomHoliday removes a user-defined day off
+func (c *Config) RemoveCustomHoliday(date string) error {
+	i := slices.IndexFunc(c.Holidays.Custom, func(h CustomHoliday) bool { return h.Date == date })
+	if i < 0 {
+		return fmt.Errorf("no custom holiday on %s", date)
+	}
+	c.Holidays.Custom = slices.Delete(c.Holidays.Custom, i, i+1)
+	return nil
+}
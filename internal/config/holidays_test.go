@@ -0,0 +1,98 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestHolidayRegions(t *testing.T) {
+	t.Run("defaults for new configs", func(t *testing.T) {
+		cm := &ConfigManager{configDir: t.TempDir(), configFile: "test_config.json"}
+		cfg, err := cm.Load()
+		if err != nil {
+			t.Fatalf("Failed to load config: %v", err)
+		}
+		regions := cfg.HolidayRegions()
+		if len(regions) != 1 || regions[0] != "NO" {
+			t.Errorf("Expected default regions [NO], got %v", regions)
+		}
+	})
+
+	t.Run("none in configs from before holidays", func(t *testing.T) {
+		var cfg Config
+		if err := json.Unmarshal([]byte(`{"paused_until":-1}`), &cfg); err != nil {
+			t.Fatalf("Failed to parse config: %v", err)
+		}
+		if regions := cfg.HolidayRegions(); len(regions) != 0 {
+			t.Errorf("Expected no regions, got %v", regions)
+		}
+	})
+
+	t.Run("empty list disables public holidays", func(t *testing.T) {
+		cfg := &Config{Holidays: HolidaySettings{Regions: []string{}}}
+
+		// the empty list must survive a save/load round trip
+		data, err := json.Marshal(cfg)
+		if err != nil {
+			t.Fatalf("Failed to marshal config: %v", err)
+		}
+		var loaded Config
+		if err := json.Unmarshal(data, &loaded); err != nil {
+			t.Fatalf("Failed to parse config: %v", err)
+		}
+		if regions := loaded.HolidayRegions(); len(regions) != 0 {
+			t.Errorf("Expected no regions, got %v", regions)
+		}
+
+		cal, err := loaded.HolidayCalendar()
+		if err != nil {
+			t.Fatalf("Failed to build calendar: %v", err)
+		}
+		if h, ok := cal.HolidayOn(time.Date(2026, 12, 25, 12, 0, 0, 0, time.Local)); ok {
+			t.Errorf("Expected no holiday, got %s", h.Name)
+		}
+	})
+
+	t.Run("invalid region", func(t *testing.T) {
+		cfg := &Config{Holidays: HolidaySettings{Regions: []string{"XX"}}}
+		if _, err := cfg.HolidayCalendar(); err == nil {
+			t.Error("Expected error for unsupported region, but got none")
+		}
+	})
+}
+
+func TestCustomHolidays(t *testing.T) {
+	cfg := &Config{}
+
+	if err := cfg.AddCustomHoliday("2026-10-16", "Office closed"); err != nil {
+		t.Fatalf("Failed to add custom holiday: %v", err)
+	}
+	if err := cfg.AddCustomHoliday("2026-10-16", "Team day"); err != nil {
+		t.Fatalf("Failed to add custom holiday: %v", err)
+	}
+	if len(cfg.Holidays.Custom) != 1 || cfg.Holidays.Custom[0].Name != "Team day" {
+		t.Errorf("Expected adding the same date to rename it, got %v", cfg.Holidays.Custom)
+	}
+	if err := cfg.AddCustomHoliday("someday", ""); err == nil {
+		t.Error("Expected error for invalid date, but got none")
+	}
+
+	cal, err := cfg.HolidayCalendar()
+	if err != nil {
+		t.Fatalf("Failed to build calendar: %v", err)
+	}
+	if h, ok := cal.HolidayOn(time.Date(2026, 10, 16, 16, 0, 0, 0, time.Local)); !ok || h.Name != "Team day" {
+		t.Errorf("Expected custom holiday, got %v (holiday: %v)", h.Name, ok)
+	}
+
+	if err := cfg.RemoveCustomHoliday("2026-10-17"); err == nil {
+		t.Error("Expected error for unknown date, but got none")
+	}
+	if err := cfg.RemoveCustomHoliday("2026-10-16"); err != nil {
+		t.Fatalf("Failed to remove custom holiday: %v", err)
+	}
+	if len(cfg.Holidays.Custom) != 0 {
+		t.Errorf("Expected no custom holidays, got %v", cfg.Holidays.Custom)
+	}
+}
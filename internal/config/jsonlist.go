@@ -0,0 +1,48 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// readList reads a JSON list from path, which is empty while the file doesn't exist.
+// what names the file in errors, e.g. "pause log".
+func readList[T any](path, what string) ([]T, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", what, err)
+	}
+
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", what, err)
+	}
+	return items, nil
+}
+
+// appendCapped appends item to the JSON list in path, dropping the oldest items when
+// there are more than limit
+func appendCapped[T any](path, what string, item T, limit int) error {
+	items, err := readList[T](path, what)
+	if err != nil {
+		return err
+	}
+
+	items = append(items, item)
+	if len(items) > limit {
+		items = items[len(items)-limit:]
+	}
+
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", what, err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", what, err)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sultengutt/assets"
+)
+
+const (
+	mantraPackDir = "mantras"
+	// CustomMantraPack is the pack mantras added with 'sultengutt mantra add' go to
+	CustomMantraPack = "custom"
+	// BuiltinMantraPack names the mantras shipped with Sultengutt
+	BuiltinMantraPack = "built-in"
+
+	maxMantraLength = 200
+)
+
+// MantraSettings controls which mantras the reminder picks from
+type MantraSettings struct {
+	Replace bool     `json:"replace,omitempty"` // use only the user's packs instead of adding them to the built-in mantras
+	Hidden  []string `json:"hidden,omitempty"`  // built-in mantras removed with 'sultengutt mantra rm'
+}
+
+// MantraPack is a JSON file of mantras in the mantras directory of the config dir,
+// a list of strings like the built-in mantras.json
+type MantraPack struct {
+	Name    string // file name without .json
+	Mantras []string
+}
+
+// Mantra is a mantra and the pack it comes from
+type Mantra struct {
+	Text string
+	Pack string
+}
+
+// MantraPackDir returns the directory user mantra packs are loaded from, empty for
+// a config that wasn't loaded from disk
+func (c *Config) MantraPackDir() string {
+	if c.configPath == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(c.configPath), mantraPackDir)
+}
+
+// LoadMantraPacks reads the packs in dir, ordered by name. A missing directory has
+// no packs.
+func LoadMantraPacks(dir string) ([]MantraPack, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mantra packs: %w", err)
+	}
+	sort.Strings(paths)
+
+	var packs []MantraPack
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read mantra pack: %w", err)
+		}
+		mantras, err := ParseMantraPack(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mantra pack %s: %w", filepath.Base(path), err)
+		}
+		packs = append(packs, MantraPack{Name: strings.TrimSuffix(filepath.Base(path), ".json"), Mantras: mantras})
+	}
+	return packs, nil
+}
+
+// ParseMantraPack parses and checks the mantras of a pack
+func ParseMantraPack(data []byte) ([]string, error) {
+	var mantras []string
+	if err := json.Unmarshal(data, &mantras); err != nil {
+		return nil, errors.New("a mantra pack must be a JSON list of strings")
+	}
+	for _, m := range mantras {
+		if err := ValidateMantra(m); err != nil {
+			return nil, err
+		}
+	}
+	return mantras, nil
+}
+
+// ValidateMantra checks that a mantra fits in the reminder
+func ValidateMantra(m string) error {
+	if strings.TrimSpace(m) == "" {
+		return errors.New("mantras can't be empty")
+	}
+	if len([]rune(m)) > maxMantraLength {
+		return fmt.Errorf("mantra is longer than %d characters: %.40s...", maxMantraLength, m)
+	}
+	return nil
+}
+
+// SaveMantraPack writes a pack to dir, replacing a pack of the same name
+func SaveMantraPack(dir string, p MantraPack) error {
+	if dir == "" {
+		return errors.New("no mantra directory")
+	}
+	if p.Name == "" || p.Name != filepath.Base(p.Name) || strings.HasPrefix(p.Name, ".") {
+		return fmt.Errorf("invalid mantra pack name: %q", p.Name)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create mantra directory: %w", err)
+	}
+	mantras := p.Mantras
+	if mantras == nil {
+		mantras = []string{}
+	}
+	data, err := json.MarshalIndent(mantras, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal mantra pack: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, p.Name+".json"), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to save mantra pack: %w", err)
+	}
+	return nil
+}
+
+// MantraPool returns the mantras the reminder picks from: the built-in ones that
+// weren't hidden and those of the user's packs, or only the packs' mantras when they
+// replace the built-in ones. Duplicates are left out.
+func (c *Config) MantraPool(packs []MantraPack) []Mantra {
+	var pool []Mantra
+	seen := make(map[string]bool)
+	add := func(text, pack string) {
+		if !seen[text] {
+			seen[text] = true
+			pool = append(pool, Mantra{Text: text, Pack: pack})
+		}
+	}
+
+	for _, p := range packs {
+		for _, m := range p.Mantras {
+			add(m, p.Name)
+		}
+	}
+	if c.Mantras.Replace && len(pool) > 0 {
+		return pool
+	}
+
+	builtin, _ := assets.Mantras()
+	var all []Mantra
+	for _, m := range builtin {
+		if !slices.Contains(c.Mantras.Hidden, m) && !seen[m] {
+			seen[m] = true
+			all = append(all, Mantra{Text: m, Pack: BuiltinMantraPack})
+		}
+	}
+	return append(all, pool...)
+}
+
+// HideMantra removes a built-in mantra from the pool
+func (c *Config) HideMantra(m string) {
+	if !slices.Contains(c.Mantras.Hidden, m) {
+		c.Mantras.Hidden = append(c.Mantras.Hidden, m)
+	}
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sultengutt/assets"
+	"testing"
+)
+
+func TestLoadMantraPacks(t *testing.T) {
+	dir := t.TempDir()
+	if packs, err := LoadMantraPacks(filepath.Join(dir, "missing")); err != nil || packs != nil {
+		t.Errorf("Expected no packs for a missing directory, got %v (error: %v)", packs, err)
+	}
+	if packs, err := LoadMantraPacks(""); err != nil || packs != nil {
+		t.Errorf("Expected no packs without a directory, got %v (error: %v)", packs, err)
+	}
+
+	if err := SaveMantraPack(dir, MantraPack{Name: "stoic", Mantras: []string{"Amor fati."}}); err != nil {
+		t.Fatalf("Failed to save pack: %v", err)
+	}
+	if err := SaveMantraPack(dir, MantraPack{Name: CustomMantraPack, Mantras: []string{"Feed the team."}}); err != nil {
+		t.Fatalf("Failed to save pack: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a pack"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	packs, err := LoadMantraPacks(dir)
+	if err != nil {
+		t.Fatalf("Failed to load packs: %v", err)
+	}
+	expected := []MantraPack{
+		{Name: CustomMantraPack, Mantras: []string{"Feed the team."}},
+		{Name: "stoic", Mantras: []string{"Amor fati."}},
+	}
+	if !slices.EqualFunc(packs, expected, func(a, b MantraPack) bool {
+		return a.Name == b.Name && slices.Equal(a.Mantras, b.Mantras)
+	}) {
+		t.Errorf("Expected %v, got %v", expected, packs)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"mantras": []}`), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if _, err := LoadMantraPacks(dir); err == nil || !strings.Contains(err.Error(), "broken.json") {
+		t.Errorf("Expected an error naming the broken pack, got %v", err)
+	}
+}
+
+func TestParseMantraPack(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{"list", `["Ship it.", "Eat well."]`, false},
+		{"empty list", `[]`, false},
+		{"object", `{"mantras": ["Ship it."]}`, true},
+		{"numbers", `[1, 2]`, true},
+		{"empty mantra", `["Ship it.", "  "]`, true},
+		{"too long", `["` + strings.Repeat("a", maxMantraLength+1) + `"]`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseMantraPack([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseMantraPack() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSaveMantraPackNames(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"", "../escape", ".hidden", "a/b"} {
+		if err := SaveMantraPack(dir, MantraPack{Name: name}); err == nil {
+			t.Errorf("Expected an error for pack name %q", name)
+		}
+	}
+	if err := SaveMantraPack("", MantraPack{Name: "stoic"}); err == nil {
+		t.Error("Expected an error without a directory")
+	}
+}
+
+func TestMantraPool(t *testing.T) {
+	builtin, err := assets.Mantras()
+	if err != nil || len(builtin) < 2 {
+		t.Fatalf("Failed to load built-in mantras: %v", err)
+	}
+	packs := []MantraPack{
+		{Name: CustomMantraPack, Mantras: []string{"Feed the team.", builtin[0]}},
+		{Name: "stoic", Mantras: []string{"Amor fati.", "Feed the team."}},
+	}
+
+	tests := []struct {
+		name     string
+		settings MantraSettings
+		packs    []MantraPack
+		expected []Mantra // the first mantras of the pool
+		size     int
+	}{
+		{"built-in", MantraSettings{}, nil, []Mantra{{builtin[0], BuiltinMantraPack}}, len(builtin)},
+		{"merged", MantraSettings{}, packs, []Mantra{{builtin[1], BuiltinMantraPack}}, len(builtin) + 2},
+		{"hidden", MantraSettings{Hidden: []string{builtin[1]}}, nil, []Mantra{{builtin[0], BuiltinMantraPack}, {builtin[2], BuiltinMantraPack}}, len(builtin) - 1},
+		{"replaced", MantraSettings{Replace: true}, packs, []Mantra{{"Feed the team.", CustomMantraPack}, {builtin[0], CustomMantraPack}, {"Amor fati.", "stoic"}}, 3},
+		{"replaced without packs", MantraSettings{Replace: true}, nil, []Mantra{{builtin[0], BuiltinMantraPack}}, len(builtin)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Mantras: tt.settings}
+			pool := cfg.MantraPool(tt.packs)
+			if len(pool) != tt.size {
+				t.Errorf("Expected %d mantras, got %d", tt.size, len(pool))
+			}
+			if len(pool) < len(tt.expected) || !slices.Equal(pool[:len(tt.expected)], tt.expected) {
+				t.Errorf("Expected the pool to start with %v, got %v", tt.expected, pool[:min(len(pool), len(tt.expected))])
+			}
+		})
+	}
+}
+
+func TestHideMantra(t *testing.T) {
+	cfg := &Config{}
+	cfg.HideMantra("Ship it.")
+	cfg.HideMantra("Ship it.")
+	if !slices.Equal(cfg.Mantras.Hidden, []string{"Ship it."}) {
+		t.Errorf("Expected the mantra to be hidden once, got %v", cfg.Mantras.Hidden)
+	}
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Notifier backends that can deliver a reminder
+const (
+	NotifierPopup        = "popup"
+	NotifierTerminal     = "terminal"
+	NotifierNotification = "notification"
+	NotifierCommand      = "command"
+	NotifierWebhook      = "webhook"
+)
+
+// Notifiers lists all notifier backends
+var Notifiers = []string{NotifierPopup, NotifierTerminal, NotifierNotification, NotifierCommand, NotifierWebhook}
+
+// DefaultNotifierOrder is used when no order has been configured
+var DefaultNotifierOrder = []string{NotifierPopup, NotifierNotification, NotifierTerminal}
+
+// NotifierSettings controls how reminders are delivered. Backends are tried in
+// order until one of them manages to show the reminder.
+type NotifierSettings struct {
+	Order   []string `json:"order,omitempty"`
+	Command string   `json:"command,omitempty"` // shell command for the command backend
+	Webhook string   `json:"webhook,omitempty"` // URL the webhook backend posts to
+}
+
+// NotifierOrder returns the configured notifier order, or the default if none is configured
+func (c *Config) NotifierOrder() []string {
+	if len(c.Notifiers.Order) == 0 {
+		return DefaultNotifierOrder
+	}
+	return c.Notifiers.Order
+}
+
+// SetNotifierOrder sets the order notifiers are tried in
+func (c *Config) SetNotifierOrder(order []string) error {
+	s := c.Notifiers
+	s.Order = order
+	if err := s.validate(); err != nil {
+		return err
+	}
+	c.Notifiers = s
+	return nil
+}
+
+// SetNotifierCommand sets the shell command run by the command notifier
+func (c *Config) SetNotifierCommand(command string) error {
+	s := c.Notifiers
+	s.Command = command
+	if err := s.validate(); err != nil {
+		return err
+	}
+	c.Notifiers = s
+	return nil
+}
+
+// SetNotifierWebhook sets the URL the webhook notifier posts to
+func (c *Config) SetNotifierWebhook(webhook string) error {
+	s := c.Notifiers
+	s.Webhook = webhook
+	if err := s.validate(); err != nil {
+		return err
+	}
+	c.Notifiers = s
+	return nil
+}
+
+func (s NotifierSettings) validate() error {
+	for i, name := range s.Order {
+		if !slices.Contains(Notifiers, name) {
+			return fmt.Errorf("unknown notifier: %s (available: %s)", name, strings.Join(Notifiers, ", "))
+		}
+		if slices.Contains(s.Order[:i], name) {
+			return fmt.Errorf("notifier %s is listed twice", name)
+		}
+	}
+	if slices.Contains(s.Order, NotifierCommand) && strings.TrimSpace(s.Command) == "" {
+		return fmt.Errorf("the command notifier needs a command")
+	}
+	if slices.Contains(s.Order, NotifierWebhook) && s.Webhook == "" {
+		return fmt.Errorf("the webhook notifier needs a URL")
+	}
+	if s.Webhook != "" {
+		u, err := url.Parse(s.Webhook)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid webhook URL: %s", s.Webhook)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNotifierOrder(t *testing.T) {
+	cfg := newTestConfig("16:00")
+	if order := cfg.NotifierOrder(); !slices.Equal(order, DefaultNotifierOrder) {
+		t.Errorf("Expected default order %v, got %v", DefaultNotifierOrder, order)
+	}
+
+	if err := cfg.SetNotifierOrder([]string{NotifierTerminal, NotifierPopup}); err != nil {
+		t.Fatalf("Failed to set order: %v", err)
+	}
+	if order := cfg.NotifierOrder(); !slices.Equal(order, []string{NotifierTerminal, NotifierPopup}) {
+		t.Errorf("Expected configured order, got %v", order)
+	}
+
+	if err := cfg.SetNotifierOrder([]string{"pigeon"}); err == nil {
+		t.Error("Expected an error for an unknown notifier")
+	}
+	if order := cfg.NotifierOrder(); !slices.Equal(order, []string{NotifierTerminal, NotifierPopup}) {
+		t.Errorf("Expected a failed update to keep the order, got %v", order)
+	}
+}
+
+func TestNotifierSettingsValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings NotifierSettings
+		wantErr  bool
+	}{
+		{"empty", NotifierSettings{}, false},
+		{"builtin backends", NotifierSettings{Order: []string{NotifierNotification, NotifierTerminal}}, false},
+		{"unknown backend", NotifierSettings{Order: []string{"fax"}}, true},
+		{"duplicate backend", NotifierSettings{Order: []string{NotifierPopup, NotifierPopup}}, true},
+		{"command without command", NotifierSettings{Order: []string{NotifierCommand}}, true},
+		{"command", NotifierSettings{Order: []string{NotifierCommand}, Command: "notify-send hi"}, false},
+		{"webhook without URL", NotifierSettings{Order: []string{NotifierWebhook}}, true},
+		{"webhook", NotifierSettings{Order: []string{NotifierWebhook}, Webhook: "https://hooks.example.com/x"}, false},
+		{"webhook with bad scheme", NotifierSettings{Webhook: "ftp://example.com"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetNotifierBackends(t *testing.T) {
+	cfg := newTestConfig("16:00")
+	if err := cfg.SetNotifierOrder([]string{NotifierWebhook}); err == nil {
+		t.Error("Expected an error when enabling the webhook without a URL")
+	}
+
+	if err := cfg.SetNotifierWebhook("https://hooks.example.com/x"); err != nil {
+		t.Fatalf("Failed to set webhook: %v", err)
+	}
+	if err := cfg.SetNotifierOrder([]string{NotifierWebhook, NotifierPopup}); err != nil {
+		t.Fatalf("Failed to set order: %v", err)
+	}
+	if err := cfg.SetNotifierWebhook(""); err == nil {
+		t.Error("Expected an error when removing the URL of an enabled webhook")
+	}
+	if cfg.Notifiers.Webhook != "https://hooks.example.com/x" {
+		t.Errorf("Expected a failed update to keep the webhook, got '%s'", cfg.Notifiers.Webhook)
+	}
+
+	if err := cfg.SetNotifierCommand("notify-send dinner"); err != nil {
+		t.Fatalf("Failed to set command: %v", err)
+	}
+	if cfg.Notifiers.Command != "notify-send dinner" {
+		t.Errorf("Expected the command to be set, got '%s'", cfg.Notifiers.Command)
+	}
+}
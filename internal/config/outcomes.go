@@ -0,0 +1,43 @@
+package config
+
+import (
+	"path/filepath"
+)
+
+const (
+	reminderLogFile = "reminder_log.json"
+	// MaxReminderLogEntries bounds how many reminder outcomes are kept
+	MaxReminderLogEntries = 200
+)
+
+// ReminderOutcome records how a delivered reminder ended
+type ReminderOutcome struct {
+	Timestamp int64  `json:"timestamp"`
+	Notifier  string `json:"notifier"`
+	Action    string `json:"action"`                   // order, skip, snooze, timeout, close or sent
+	SnoozeFor int64  `json:"snooze_seconds,omitempty"` // for snoozes: how long in seconds
+	Vendor    string `json:"vendor,omitempty"`         // for orders: the vendor ordered from
+}
+
+// ReminderLog returns the recorded reminder outcomes, oldest first
+func (cm *ConfigManager) ReminderLog() ([]ReminderOutcome, error) {
+	return readList[ReminderOutcome](cm.reminderLogPath(), "reminder log")
+}
+
+// LastReminder returns the most recent reminder outcome, if any
+func (cm *ConfigManager) LastReminder() (ReminderOutcome, bool, error) {
+	outcomes, err := cm.ReminderLog()
+	if err != nil || len(outcomes) == 0 {
+		return ReminderOutcome{}, false, err
+	}
+	return outcomes[len(outcomes)-1], true, nil
+}
+
+// RecordReminderOutcome appends an outcome to the reminder log, dropping the oldest when it's full
+func (cm *ConfigManager) RecordReminderOutcome(outcome ReminderOutcome) error {
+	return appendCapped(cm.reminderLogPath(), "reminder log", outcome, MaxReminderLogEntries)
+}
+
+func (cm *ConfigManager) reminderLogPath() string {
+	return filepath.Join(cm.configDir, reminderLogFile)
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestReminderLog(t *testing.T) {
+	cm := &ConfigManager{
+		configDir:  t.TempDir(),
+		configFile: "test_config.json",
+	}
+
+	if _, ok, err := cm.LastReminder(); err != nil || ok {
+		t.Fatalf("Expected no last reminder, got ok=%v err=%v", ok, err)
+	}
+
+	if err := cm.RecordReminderOutcome(ReminderOutcome{Timestamp: 100, Notifier: "popup", Action: "snooze", SnoozeFor: 600}); err != nil {
+		t.Fatalf("Failed to record outcome: %v", err)
+	}
+	if err := cm.RecordReminderOutcome(ReminderOutcome{Timestamp: 700, Notifier: "popup", Action: "order"}); err != nil {
+		t.Fatalf("Failed to record outcome: %v", err)
+	}
+
+	outcomes, err := cm.ReminderLog()
+	if err != nil {
+		t.Fatalf("Failed to read reminder log: %v", err)
+	}
+	if len(outcomes) != 2 || outcomes[0].SnoozeFor != 600 {
+		t.Fatalf("Unexpected outcomes %+v", outcomes)
+	}
+	last, ok, err := cm.LastReminder()
+	if err != nil || !ok || last.Action != "order" || last.Timestamp != 700 {
+		t.Errorf("Expected the order as last reminder, got %+v ok=%v err=%v", last, ok, err)
+	}
+
+	for i := 0; i < MaxReminderLogEntries; i++ {
+		if err := cm.RecordReminderOutcome(ReminderOutcome{Timestamp: int64(1000 + i), Action: "timeout"}); err != nil {
+			t.Fatalf("Failed to record outcome: %v", err)
+		}
+	}
+	outcomes, _ = cm.ReminderLog()
+	if len(outcomes) != MaxReminderLogEntries {
+		t.Errorf("Expected %d outcomes, got %d", MaxReminderLogEntries, len(outcomes))
+	}
+	if outcomes[0].Timestamp != 1000 {
+		t.Errorf("Expected the oldest outcomes to be dropped, got first timestamp %d", outcomes[0].Timestamp)
+	}
+}
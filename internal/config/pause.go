@@ -0,0 +1,73 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+)
+
+// PauseWindow is a planned pause, e.g. a vacation, between two unix timestamps
+type PauseWindow struct {
+	ID     int    `json:"id"`
+	From   int64  `json:"from"`
+	To     int64  `json:"to"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Contains reports whether t falls inside the window. The end is exclusive.
+func (w PauseWindow) Contains(t time.Time) bool {
+	return t.Unix() >= w.From && t.Unix() < w.To
+}
+
+// AddPauseWindow stores a new planned pause and returns it with its assigned ID
+func (c *Config) AddPauseWindow(from, to time.Time, reason string) (PauseWindow, error) {
+	if !to.After(from) {
+		return PauseWindow{}, errors.New("end of pause must be after the start")
+	}
+
+	id := 1
+	for _, w := range c.PauseWindows {
+		if w.ID >= id {
+			id = w.ID + 1
+		}
+	}
+
+	window := PauseWindow{ID: id, From: from.Unix(), To: to.Unix(), Reason: reason}
+	c.PauseWindows = append(c.PauseWindows, window)
+	sort.Slice(c.PauseWindows, func(i, j int) bool {
+		return c.PauseWindows[i].From < c.PauseWindows[j].From
+	})
+	return window, nil
+}
+
+// RemovePauseWindow deletes the planned pause with the given ID and returns it
+func (c *Config) RemovePauseWindow(id int) (PauseWindow, error) {
+	i := slices.IndexFunc(c.PauseWindows, func(w PauseWindow) bool { return w.ID == id })
+	if i < 0 {
+		return PauseWindow{}, fmt.Errorf("no planned pause with ID %d", id)
+	}
+	w := c.PauseWindows[i]
+	c.PauseWindows = slices.Delete(c.PauseWindows, i, i+1)
+	return w, nil
+}
+
+// ActivePauseWindow returns the planned pause covering t, if any
+func (c *Config) ActivePauseWindow(t time.Time) (PauseWindow, bool) {
+	for _, w := range c.PauseWindows {
+		if w.Contains(t) {
+			return w, true
+		}
+	}
+	return PauseWindow{}, false
+}
+
+// PruneExpiredPauseWindows removes planned pauses that ended before t and reports whether any were removed
+func (c *Config) PruneExpiredPauseWindows(t time.Time) bool {
+	before := len(c.PauseWindows)
+	c.PauseWindows = slices.DeleteFunc(c.PauseWindows, func(w PauseWindow) bool {
+		return w.To <= t.Unix()
+	})
+	return len(c.PauseWindows) != before
+}
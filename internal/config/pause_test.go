@@ -0,0 +1,105 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPauseWindows(t *testing.T) {
+	cfg := &Config{PausedUntil: -1}
+
+	christmas, err := cfg.AddPauseWindow(
+		time.Date(2026, 12, 21, 0, 0, 0, 0, time.UTC),
+		time.Date(2027, 1, 4, 0, 0, 0, 0, time.UTC),
+		"christmas",
+	)
+	if err != nil {
+		t.Fatalf("Failed to add pause window: %v", err)
+	}
+	ski, err := cfg.AddPauseWindow(
+		time.Date(2026, 2, 16, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 23, 0, 0, 0, 0, time.UTC),
+		"",
+	)
+	if err != nil {
+		t.Fatalf("Failed to add pause window: %v", err)
+	}
+
+	if christmas.ID == ski.ID {
+		t.Errorf("Expected unique IDs, got %d twice", christmas.ID)
+	}
+	if cfg.PauseWindows[0].ID != ski.ID {
+		t.Error("Expected pause windows to be sorted by start")
+	}
+
+	t.Run("invalid window", func(t *testing.T) {
+		from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+		if _, err := cfg.AddPauseWindow(from, from, ""); err == nil {
+			t.Error("Expected error for empty window, but got none")
+		}
+	})
+
+	t.Run("active window", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			at       time.Time
+			expected int
+		}{
+			{"inside christmas", time.Date(2026, 12, 24, 17, 0, 0, 0, time.UTC), christmas.ID},
+			{"start is inclusive", time.Date(2026, 12, 21, 0, 0, 0, 0, time.UTC), christmas.ID},
+			{"end is exclusive", time.Date(2027, 1, 4, 0, 0, 0, 0, time.UTC), 0},
+			{"inside ski week", time.Date(2026, 2, 18, 12, 0, 0, 0, time.UTC), ski.ID},
+			{"between windows", time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC), 0},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				w, ok := cfg.ActivePauseWindow(tt.at)
+				if tt.expected == 0 {
+					if ok {
+						t.Errorf("Expected no active window, got %d", w.ID)
+					}
+					return
+				}
+				if !ok || w.ID != tt.expected {
+					t.Errorf("Expected active window %d, got %d (active: %v)", tt.expected, w.ID, ok)
+				}
+			})
+		}
+	})
+
+	t.Run("prune expired", func(t *testing.T) {
+		if !cfg.PruneExpiredPauseWindows(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Error("Expected the ski week to be pruned")
+		}
+		if len(cfg.PauseWindows) != 1 || cfg.PauseWindows[0].ID != christmas.ID {
+			t.Errorf("Expected only christmas to remain, got %v", cfg.PauseWindows)
+		}
+		if cfg.PruneExpiredPauseWindows(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Error("Expected nothing to be pruned the second time")
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		if _, err := cfg.RemovePauseWindow(99); err == nil {
+			t.Error("Expected error for unknown ID, but got none")
+		}
+		if _, err := cfg.RemovePauseWindow(christmas.ID); err != nil {
+			t.Fatalf("Failed to remove pause window: %v", err)
+		}
+		if len(cfg.PauseWindows) != 0 {
+			t.Errorf("Expected no pause windows, got %v", cfg.PauseWindows)
+		}
+	})
+
+	t.Run("ids are not reused", func(t *testing.T) {
+		cfg := &Config{PauseWindows: []PauseWindow{{ID: 3, From: 1, To: 2}}}
+		w, err := cfg.AddPauseWindow(time.Unix(10, 0), time.Unix(20, 0), "")
+		if err != nil {
+			t.Fatalf("Failed to add pause window: %v", err)
+		}
+		if w.ID != 4 {
+			t.Errorf("Expected ID 4, got %d", w.ID)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// PopupNeverCloses as the popup timeout keeps the popup open until the user answers
+const PopupNeverCloses = -1
+
+// popupImageExtensions are the image formats every popup implementation can show
+var popupImageExtensions = []string{".png", ".jpg", ".jpeg"}
+
+// PopupSettings customizes the reminder content. Empty fields keep the defaults.
+type PopupSettings struct {
+	Title           string              `json:"title,omitempty"`
+	Emoji           string              `json:"emoji,omitempty"`
+	Messages        []string            `json:"messages,omitempty"`         // subtitles, one picked at random
+	WeekdayMessages map[string][]string `json:"weekday_messages,omitempty"` // subtitles by weekday, e.g. "Friday"
+	ImagePath       string              `json:"image_path,omitempty"`       // local image shown instead of the emoji
+	OrderLabel      string              `json:"order_label,omitempty"`
+	SkipLabel       string              `json:"skip_label,omitempty"`
+	TimeoutSeconds  int                 `json:"timeout_seconds,omitempty"` // 0: default, -1: never closes by itself
+	Theme           ThemeSettings       `json:"theme"`
+}
+
+// MessagesFor returns the subtitles to pick from on day, preferring the messages for
+// that weekday. It returns nil when no messages are configured.
+func (p PopupSettings) MessagesFor(day time.Weekday) []string {
+	if messages := p.WeekdayMessages[day.String()]; len(messages) > 0 {
+		return messages
+	}
+	if len(p.Messages) > 0 {
+		return p.Messages
+	}
+	return nil
+}
+
+// Timeout returns how long the popup stays open, 0 meaning until the user answers.
+// ok is false when no timeout is configured.
+func (p PopupSettings) Timeout() (timeout time.Duration, ok bool) {
+	switch p.TimeoutSeconds {
+	case 0:
+		return 0, false
+	case PopupNeverCloses:
+		return 0, true
+	}
+	return time.Duration(p.TimeoutSeconds) * time.Second, true
+}
+
+func (p PopupSettings) validate() error {
+	if p.TimeoutSeconds < PopupNeverCloses {
+		return fmt.Errorf("invalid timeout: %d seconds (use -1 to keep the popup open)", p.TimeoutSeconds)
+	}
+	if err := validateMessages(p.Messages); err != nil {
+		return err
+	}
+	for day, messages := range p.WeekdayMessages {
+		if !slices.Contains(validDays, day) {
+			return fmt.Errorf("invalid weekday for messages: %s", day)
+		}
+		if err := validateMessages(messages); err != nil {
+			return fmt.Errorf("%s: %w", day, err)
+		}
+	}
+	if err := p.Theme.validate(); err != nil {
+		return err
+	}
+	if p.ImagePath != "" {
+		if !filepath.IsAbs(p.ImagePath) {
+			return fmt.Errorf("image path must be absolute: %s", p.ImagePath)
+		}
+		if ext := strings.ToLower(filepath.Ext(p.ImagePath)); !slices.Contains(popupImageExtensions, ext) {
+			return fmt.Errorf("unsupported image format: %s (use %s)", p.ImagePath, strings.Join(popupImageExtensions, ", "))
+		}
+	}
+	return nil
+}
+
+func validateMessages(messages []string) error {
+	for _, m := range messages {
+		if strings.TrimSpace(m) == "" {
+			return fmt.Errorf("messages must not be empty")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPopupMessagesFor(t *testing.T) {
+	p := PopupSettings{
+		Messages:        []string{"Dinner time"},
+		WeekdayMessages: map[string][]string{"Friday": {"Pizza Friday!"}},
+	}
+
+	tests := []struct {
+		day      time.Weekday
+		expected []string
+	}{
+		{time.Friday, []string{"Pizza Friday!"}},
+		{time.Monday, []string{"Dinner time"}},
+	}
+	for _, tt := range tests {
+		if got := p.MessagesFor(tt.day); !slices.Equal(got, tt.expected) {
+			t.Errorf("MessagesFor(%s) = %v, expected %v", tt.day, got, tt.expected)
+		}
+	}
+
+	if got := (PopupSettings{}).MessagesFor(time.Friday); got != nil {
+		t.Errorf("Expected no messages when none are configured, got %v", got)
+	}
+}
+
+func TestPopupTimeout(t *testing.T) {
+	tests := []struct {
+		seconds    int
+		expected   time.Duration
+		expectedOK bool
+	}{
+		{0, 0, false},
+		{PopupNeverCloses, 0, true},
+		{90, 90 * time.Second, true},
+	}
+
+	for _, tt := range tests {
+		got, ok := PopupSettings{TimeoutSeconds: tt.seconds}.Timeout()
+		if got != tt.expected || ok != tt.expectedOK {
+			t.Errorf("Timeout() with %d seconds = %v, %v, expected %v, %v", tt.seconds, got, ok, tt.expected, tt.expectedOK)
+		}
+	}
+}
+
+func TestValidatePopupSettings(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		settings PopupSettings
+		wantErr  bool
+	}{
+		{"empty", PopupSettings{}, false},
+		{"full", PopupSettings{
+			Title:           "Dinner",
+			Messages:        []string{"Order now"},
+			WeekdayMessages: map[string][]string{"Friday": {"Pizza Friday!"}},
+			ImagePath:       filepath.Join(dir, "logo.PNG"),
+			TimeoutSeconds:  60,
+		}, false},
+		{"never closes", PopupSettings{TimeoutSeconds: PopupNeverCloses}, false},
+		{"negative timeout", PopupSettings{TimeoutSeconds: -5}, true},
+		{"blank message", PopupSettings{Messages: []string{"  "}}, true},
+		{"unknown weekday", PopupSettings{WeekdayMessages: map[string][]string{"Funday": {"Hi"}}}, true},
+		{"blank weekday message", PopupSettings{WeekdayMessages: map[string][]string{"Monday": {""}}}, true},
+		{"relative image", PopupSettings{ImagePath: "logo.png"}, true},
+		{"unsupported image", PopupSettings{ImagePath: filepath.Join(dir, "logo.svg")}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultRefireAttempts is how often a reminder is shown when nobody answers it
+const DefaultRefireAttempts = 3
+
+// RefireSettings shows a reminder again when its popup timed out without an answer,
+// e.g. because nobody was at the desk
+type RefireSettings struct {
+	AfterMinutes int `json:"after_minutes,omitempty"` // wait before showing it again, 0 turns re-firing off
+	MaxAttempts  int `json:"max_attempts,omitempty"`  // times the reminder is shown in total, 0 for the default
+}
+
+// Enabled reports whether timed out reminders are shown again
+func (r RefireSettings) Enabled() bool {
+	return r.AfterMinutes > 0
+}
+
+// After returns how long to wait before showing a timed out reminder again
+func (r RefireSettings) After() time.Duration {
+	return time.Duration(r.AfterMinutes) * time.Minute
+}
+
+// Attempts returns how often the reminder is shown at most, the first time included
+func (r RefireSettings) Attempts() int {
+	if r.MaxAttempts == 0 {
+		return DefaultRefireAttempts
+	}
+	return r.MaxAttempts
+}
+
+func (r RefireSettings) validate() error {
+	if r.AfterMinutes < 0 || r.AfterMinutes >= 24*60 {
+		return fmt.Errorf("invalid re-fire delay: %d minutes (must be between 1 and 1439)", r.AfterMinutes)
+	}
+	if r.MaxAttempts < 0 || r.MaxAttempts > 20 {
+		return fmt.Errorf("invalid re-fire attempts: %d (must be between 1 and 20)", r.MaxAttempts)
+	}
+	return nil
+}
+
+// SetRefire sets the re-fire policy
+func (c *Config) SetRefire(r RefireSettings) error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+	c.Refire = r
+	return nil
+}
+
+// NextRefire returns when a reminder that was shown for the attempt-th time and timed
+// out at now is shown again. It reports false when the attempts are used up, the
+// order cutoff would have passed or re-firing is off.
+func (c *Config) NextRefire(attempt int, now time.Time) (time.Time, bool) {
+	if !c.Refire.Enabled() || attempt >= c.Refire.Attempts() {
+		return time.Time{}, false
+	}
+	at := now.Add(c.Refire.After())
+	if cutoff, ok := c.Cutoff(now); ok && at.After(cutoff) {
+		return time.Time{}, false
+	}
+	return at, true
+}
+
+// CurrentAttempt returns how many times the snoozed reminder has been shown when it
+// fires, counting the upcoming showing. It is 1 unless the reminder is shown again
+// after a timeout.
+func (c *Config) CurrentAttempt() int {
+	return max(c.State.RefireAttempt, 1)
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateRefireSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings RefireSettings
+		wantErr  bool
+	}{
+		{"off", RefireSettings{}, false},
+		{"default attempts", RefireSettings{AfterMinutes: 15}, false},
+		{"attempts", RefireSettings{AfterMinutes: 15, MaxAttempts: 5}, false},
+		{"negative delay", RefireSettings{AfterMinutes: -1}, true},
+		{"delay of a day", RefireSettings{AfterMinutes: 24 * 60}, true},
+		{"negative attempts", RefireSettings{AfterMinutes: 15, MaxAttempts: -1}, true},
+		{"too many attempts", RefireSettings{AfterMinutes: 15, MaxAttempts: 21}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNextRefire(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name     string
+		refire   RefireSettings
+		cutoff   string
+		attempt  int
+		expected time.Time // zero when the reminder isn't shown again
+	}{
+		{"off", RefireSettings{}, "", 1, time.Time{}},
+		{"first timeout", RefireSettings{AfterMinutes: 15}, "", 1, now.Add(15 * time.Minute)},
+		{"attempts used up", RefireSettings{AfterMinutes: 15}, "", 3, time.Time{}},
+		{"more attempts", RefireSettings{AfterMinutes: 15, MaxAttempts: 5}, "", 4, now.Add(15 * time.Minute)},
+		{"before cutoff", RefireSettings{AfterMinutes: 15}, "14:15", 1, now.Add(15 * time.Minute)},
+		{"after cutoff", RefireSettings{AfterMinutes: 15}, "14:10", 1, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Refire: tt.refire, OrderCutoff: tt.cutoff}
+			at, ok := cfg.NextRefire(tt.attempt, now)
+			if tt.expected.IsZero() {
+				if ok {
+					t.Errorf("Expected no re-fire, got %v", at)
+				}
+				return
+			}
+			if !ok || !at.Equal(tt.expected) {
+				t.Errorf("Expected re-fire at %v, got %v (ok: %v)", tt.expected, at, ok)
+			}
+		})
+	}
+}
+
+func TestCurrentAttempt(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.CurrentAttempt(); got != 1 {
+		t.Errorf("Expected attempt 1, got %d", got)
+	}
+	cfg.State.RefireAttempt = 2
+	if got := cfg.CurrentAttempt(); got != 2 {
+		t.Errorf("Expected attempt 2, got %d", got)
+	}
+	// a snooze is a new reminder
+	cfg.SnoozeUntil(time.Now().Add(10 * time.Minute))
+	if got := cfg.CurrentAttempt(); got != 1 {
+		t.Errorf("Expected a snooze to reset the attempt, got %d", got)
+	}
+}
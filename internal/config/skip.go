@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"sultengutt/internal/utils"
+	"time"
+)
+
+const skipDateFormat = "2006-01-02"
+
+// AddSkip marks the reminder on the day of t to be skipped. It reports false if it was already skipped.
+func (c *Config) AddSkip(t time.Time) bool {
+	date := t.Format(skipDateFormat)
+	if slices.Contains(c.Skips, date) {
+		return false
+	}
+	c.Skips = append(c.Skips, date)
+	sort.Strings(c.Skips)
+	return true
+}
+
+// RemoveSkip cancels a skip by its date (YYYY-MM-DD)
+func (c *Config) RemoveSkip(date string) error {
+	i := slices.Index(c.Skips, date)
+	if i < 0 {
+		return fmt.Errorf("no skipped reminder on %s", date)
+	}
+	c.Skips = slices.Delete(c.Skips, i, i+1)
+	return nil
+}
+
+// IsSkipped reports whether the reminder on the day of t is skipped
+func (c *Config) IsSkipped(t time.Time) bool {
+	return slices.Contains(c.Skips, t.Format(skipDateFormat))
+}
+
+// PruneSkips removes skips for days before t and reports whether any were removed
+func (c *Config) PruneSkips(t time.Time) bool {
+	today := t.Format(skipDateFormat)
+	before := len(c.Skips)
+	// dates in this format sort chronologically as strings
+	c.Skips = slices.DeleteFunc(c.Skips, func(date string) bool { return date < today })
+	return len(c.Skips) != before
+}
+
+// NextReminders returns the next n reminders after `from` that will actually fire,
+// leaving out skipped days, pauses, planned pauses and holidays. There are none
+// while paused indefinitely.
+func (c *Config) NextReminders(from time.Time, n int) ([]time.Time, error) {
+	if c.PausedUntil == 0 {
+		return nil, nil
+	}
+	cal, err := c.HolidayCalendar()
+	if err != nil {
+		return nil, err
+	}
+
+	var reminders []time.Time
+	cursor := from
+	if c.PausedUntil > from.Unix() {
+		// a reminder due right when the pause ends fires, like in 'sultengutt execute'
+		cursor = time.Unix(c.PausedUntil-1, 0).In(from.Location())
+	}
+	// stop eventually if everything is skipped, e.g. a year-long planned pause
+	for tries := 0; len(reminders) < n && tries < 1000; tries++ {
+		next, err := utils.NextOccurrences(c.InstallOptions.Days, c.InstallOptions.Hour, cursor, 1)
+		if err != nil {
+			return nil, err
+		}
+		cursor = next[0]
+		if c.IsSkipped(cursor) {
+			continue
+		}
+		if _, ok := c.ActivePauseWindow(cursor); ok {
+			continue
+		}
+		if _, ok := cal.HolidayOn(cursor); ok {
+			continue
+		}
+		reminders = append(reminders, cursor)
+	}
+	return reminders, nil
+}
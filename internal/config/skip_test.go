@@ -0,0 +1,120 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSkips(t *testing.T) {
+	cfg := newTestConfig("16:00")
+
+	friday := time.Date(2026, 10, 16, 16, 0, 0, 0, time.Local)
+	monday := time.Date(2026, 10, 19, 16, 0, 0, 0, time.Local)
+
+	if !cfg.AddSkip(monday) || !cfg.AddSkip(friday) {
+		t.Fatal("Expected skips to be added")
+	}
+	if cfg.AddSkip(friday.Add(-time.Hour)) {
+		t.Error("Expected a second skip on the same day to be ignored")
+	}
+	if cfg.Skips[0] != "2026-10-16" || cfg.Skips[1] != "2026-10-19" {
+		t.Errorf("Expected sorted skips, got %v", cfg.Skips)
+	}
+
+	if !cfg.IsSkipped(friday.Add(2 * time.Hour)) {
+		t.Error("Expected friday to be skipped")
+	}
+	if cfg.IsSkipped(friday.AddDate(0, 0, 7)) {
+		t.Error("Expected the friday after to not be skipped")
+	}
+
+	if !cfg.PruneSkips(time.Date(2026, 10, 17, 0, 0, 0, 0, time.Local)) {
+		t.Error("Expected the friday skip to be pruned")
+	}
+	if len(cfg.Skips) != 1 || cfg.Skips[0] != "2026-10-19" {
+		t.Errorf("Expected only monday to remain, got %v", cfg.Skips)
+	}
+	if cfg.PruneSkips(time.Date(2026, 10, 19, 23, 0, 0, 0, time.Local)) {
+		t.Error("Expected today's skip to be kept")
+	}
+
+	if err := cfg.RemoveSkip("2026-10-20"); err == nil {
+		t.Error("Expected error for unknown skip, but got none")
+	}
+	if err := cfg.RemoveSkip("2026-10-19"); err != nil {
+		t.Fatalf("Failed to remove skip: %v", err)
+	}
+	if len(cfg.Skips) != 0 {
+		t.Errorf("Expected no skips, got %v", cfg.Skips)
+	}
+}
+
+func TestNextReminders(t *testing.T) {
+	// Monday and Friday at 16:00, starting Wednesday October 14 2026
+	cfg := newTestConfig("16:00")
+	cfg.Holidays.Regions = []string{"NO"}
+	now := time.Date(2026, 10, 14, 10, 0, 0, 0, time.Local)
+
+	cfg.AddSkip(time.Date(2026, 10, 16, 0, 0, 0, 0, time.Local))
+	if _, err := cfg.AddPauseWindow(
+		time.Date(2026, 10, 19, 0, 0, 0, 0, time.Local),
+		time.Date(2026, 10, 20, 0, 0, 0, 0, time.Local),
+		"",
+	); err != nil {
+		t.Fatalf("Failed to add pause window: %v", err)
+	}
+
+	reminders, err := cfg.NextReminders(now, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []time.Time{
+		time.Date(2026, 10, 23, 16, 0, 0, 0, time.Local),
+		time.Date(2026, 10, 26, 16, 0, 0, 0, time.Local),
+	}
+	if len(reminders) != len(expected) {
+		t.Fatalf("Expected %d reminders, got %v", len(expected), reminders)
+	}
+	for i := range reminders {
+		if !reminders[i].Equal(expected[i]) {
+			t.Errorf("Reminder %d: expected %v, got %v", i, expected[i], reminders[i])
+		}
+	}
+
+	t.Run("holidays are left out", func(t *testing.T) {
+		// Christmas Day 2026 is a Friday
+		reminders, err := cfg.NextReminders(time.Date(2026, 12, 22, 0, 0, 0, 0, time.Local), 1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := time.Date(2026, 12, 28, 16, 0, 0, 0, time.Local)
+		if len(reminders) != 1 || !reminders[0].Equal(expected) {
+			t.Errorf("Expected %v, got %v", expected, reminders)
+		}
+	})
+	t.Run("pauses are left out", func(t *testing.T) {
+		paused := newTestConfig("16:00")
+		paused.PausedUntil = time.Date(2026, 10, 19, 16, 0, 0, 0, time.Local).Unix()
+		reminders, err := paused.NextReminders(now, 2)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := []time.Time{
+			time.Date(2026, 10, 19, 16, 0, 0, 0, time.Local),
+			time.Date(2026, 10, 23, 16, 0, 0, 0, time.Local),
+		}
+		if len(reminders) != 2 || !reminders[0].Equal(expected[0]) || !reminders[1].Equal(expected[1]) {
+			t.Errorf("Expected %v, got %v", expected, reminders)
+		}
+	})
+
+	t.Run("none while paused indefinitely", func(t *testing.T) {
+		paused := newTestConfig("16:00")
+		paused.PausedUntil = 0
+		reminders, err := paused.NextReminders(now, 2)
+		if err != nil || len(reminders) != 0 {
+			t.Errorf("Expected no reminders, got %v (error: %v)", reminders, err)
+		}
+	})
+}
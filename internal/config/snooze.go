@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// SnoozeDurations returns the configured snooze durations, or nil if none are
+// configured. An empty list means the reminder can't be snoozed.
+func (c *Config) SnoozeDurations() []time.Duration {
+	if c.SnoozeMinutes == nil {
+		return nil
+	}
+	durations := make([]time.Duration, 0, len(c.SnoozeMinutes))
+	for _, minutes := range c.SnoozeMinutes {
+		durations = append(durations, time.Duration(minutes)*time.Minute)
+	}
+	return durations
+}
+
+// SnoozeUntil snoozes the reminder until t. A snooze starts a new reminder, so the
+// count of a reminder shown again after timeouts is reset.
+func (c *Config) SnoozeUntil(t time.Time) {
+	c.State.SnoozedUntil = t.Unix()
+	c.State.RefireAttempt = 0
+}
+
+// Unsnooze cancels a snoozed reminder
+func (c *Config) Unsnooze() {
+	c.State.SnoozedUntil = 0
+}
+
+// IsSnoozed reports whether a snoozed reminder is waiting to be shown again after now
+func (c *Config) IsSnoozed(now time.Time) bool {
+	return c.State.SnoozedUntil > now.Unix()
+}
+
+func validateSnoozeMinutes(minutes []int) error {
+	for _, m := range minutes {
+		if m <= 0 || m >= 24*60 {
+			return fmt.Errorf("invalid snooze duration: %d minutes (must be between 1 and 1439)", m)
+		}
+	}
+	return nil
+}
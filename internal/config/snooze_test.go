@@ -0,0 +1,78 @@
+package config
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSnoozeDurations(t *testing.T) {
+	cfg := newTestConfig("16:00")
+	if d := cfg.SnoozeDurations(); d != nil {
+		t.Errorf("Expected no durations when not configured, got %v", d)
+	}
+
+	cfg.SnoozeMinutes = []int{5, 90}
+	expected := []time.Duration{5 * time.Minute, 90 * time.Minute}
+	if d := cfg.SnoozeDurations(); !slices.Equal(d, expected) {
+		t.Errorf("Expected %v, got %v", expected, d)
+	}
+
+	// an empty list disables snoozing and must survive a save/load round trip
+	cfg.SnoozeMinutes = []int{}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Failed to marshal config: %v", err)
+	}
+	var loaded Config
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	if d := loaded.SnoozeDurations(); d == nil || len(d) != 0 {
+		t.Errorf("Expected an empty list, got %v", d)
+	}
+}
+
+func TestSnoozeState(t *testing.T) {
+	now := time.Date(2026, 10, 19, 16, 0, 0, 0, time.UTC)
+	cfg := newTestConfig("16:00")
+
+	if cfg.IsSnoozed(now) {
+		t.Error("Expected a new config not to be snoozed")
+	}
+
+	cfg.SnoozeUntil(now.Add(30 * time.Minute))
+	if !cfg.IsSnoozed(now) {
+		t.Error("Expected the reminder to be snoozed")
+	}
+	if cfg.IsSnoozed(now.Add(time.Hour)) {
+		t.Error("Expected the snooze to be over after it ended")
+	}
+
+	cfg.Unsnooze()
+	if cfg.IsSnoozed(now) || cfg.State.SnoozedUntil != 0 {
+		t.Error("Expected the snooze to be cancelled")
+	}
+}
+
+func TestValidateSnoozeMinutes(t *testing.T) {
+	tests := []struct {
+		minutes []int
+		wantErr bool
+	}{
+		{nil, false},
+		{[]int{}, false},
+		{[]int{10, 30, 60}, false},
+		{[]int{0}, true},
+		{[]int{-5}, true},
+		{[]int{24 * 60}, true},
+	}
+
+	for _, tt := range tests {
+		err := validateSnoozeMinutes(tt.minutes)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateSnoozeMinutes(%v) error = %v, wantErr %v", tt.minutes, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sultengutt/assets"
+)
+
+const stateFile = "state.json"
+
+// State is what sultengutt keeps track of while running, e.g. a pending snooze. It is
+// saved next to the config rather than in it, so it stays out of the config history.
+type State struct {
+	SnoozedUntil  int64  `json:"snoozed_until,omitempty"`  // unix timestamp the snoozed reminder is shown again at
+	RefireAttempt int    `json:"refire_attempt,omitempty"` // showing of the snoozed reminder when it was re-fired after a timeout
+	LastVendor    string `json:"last_vendor,omitempty"`    // name of the vendor last ordered from
+	// MantraRotation picks the mantra of the day, see assets.MantraLoader.Next
+	MantraRotation assets.MantraRotation `json:"mantra_rotation"`
+}
+
+// loadState reads the state into cfg. Older configs kept the state in the config
+// data, which is used until the state file has been written. A broken state file is
+// started over rather than failing every command, as losing the state only loses
+// e.g. a pending snooze.
+func (cm *ConfigManager) loadState(cfg *Config, configData []byte) error {
+	data, err := os.ReadFile(cm.statePath())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read state: %w", err)
+		}
+		data = configData
+	}
+	if err := json.Unmarshal(data, &cfg.State); err != nil {
+		cfg.State = State{}
+	}
+	return nil
+}
+
+func (cm *ConfigManager) saveState(s State) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+	if err := writeFileAtomic(cm.statePath(), data); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	return nil
+}
+
+func (cm *ConfigManager) statePath() string {
+	return filepath.Join(cm.configDir, stateFile)
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sultengutt/assets"
+	"testing"
+)
+
+func TestStateSavedNextToConfig(t *testing.T) {
+	cm := &ConfigManager{
+		configDir:  t.TempDir(),
+		configFile: "test_config.json",
+	}
+
+	cfg := newTestConfig("14:30")
+	if err := cm.SaveWithHistory(cfg); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+	cfg.State = State{
+		SnoozedUntil:   12345,
+		RefireAttempt:  2,
+		LastVendor:     "Foodora",
+		MantraRotation: assets.MantraRotation{Day: "2026-10-16", Mantra: "Ship it.", Queue: []string{"Eat well."}},
+	}
+	if err := cm.Save(cfg); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(cm.configDir, cm.configFile))
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	if strings.Contains(string(data), "snoozed_until") || strings.Contains(string(data), "Foodora") {
+		t.Errorf("Expected no state in the config, got:\n%s", data)
+	}
+
+	loaded, err := cm.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.State, cfg.State) {
+		t.Errorf("Expected state %+v, got %+v", cfg.State, loaded.State)
+	}
+
+	// Undoing restores the user's settings, not the state
+	loaded.PausedUntil = 0
+	if err := cm.SaveWithHistory(loaded); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+	if err := cm.Undo(loaded, 1); err != nil {
+		t.Fatalf("Failed to undo: %v", err)
+	}
+	if loaded.PausedUntil != -1 || !reflect.DeepEqual(loaded.State, cfg.State) {
+		t.Errorf("Expected the pause undone and the state kept, got %d and %+v", loaded.PausedUntil, loaded.State)
+	}
+}
+
+func TestStateMigratedFromConfig(t *testing.T) {
+	cm := &ConfigManager{
+		configDir:  t.TempDir(),
+		configFile: "test_config.json",
+	}
+	legacy := `{
+  "install_options": {"days": ["Monday"], "hour": "14:30", "sitelink": "https://example.com"},
+  "paused_until": -1,
+  "snoozed_until": 12345,
+  "refire_attempt": 2,
+  "last_vendor": "Foodora"
+}`
+	if err := os.WriteFile(filepath.Join(cm.configDir, cm.configFile), []byte(legacy), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := cm.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	expected := State{SnoozedUntil: 12345, RefireAttempt: 2, LastVendor: "Foodora"}
+	if !reflect.DeepEqual(cfg.State, expected) {
+		t.Errorf("Expected state %+v from the config, got %+v", expected, cfg.State)
+	}
+
+	// Once saved, the state file wins over the fields left in old configs
+	cfg.State = State{}
+	if err := cm.saveState(cfg.State); err != nil {
+		t.Fatalf("Failed to save state: %v", err)
+	}
+	cfg, err = cm.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.State, State{}) {
+		t.Errorf("Expected the saved state, got %+v", cfg.State)
+	}
+}
+
+func TestBrokenState(t *testing.T) {
+	cm := &ConfigManager{
+		configDir:  t.TempDir(),
+		configFile: "test_config.json",
+	}
+	if err := cm.Save(newTestConfig("14:30")); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+	if err := os.WriteFile(cm.statePath(), []byte("{"), 0644); err != nil {
+		t.Fatalf("Failed to write state: %v", err)
+	}
+	cfg, err := cm.Load()
+	if err != nil {
+		t.Fatalf("Expected a broken state file not to fail loading, got %v", err)
+	}
+	if !reflect.DeepEqual(cfg.State, State{}) {
+		t.Errorf("Expected the state to start over, got %+v", cfg.State)
+	}
+}
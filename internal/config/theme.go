@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"image/color"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Popup theme modes
+const (
+	ThemeDark   = "dark"
+	ThemeLight  = "light"
+	ThemeSystem = "system"
+	ThemeCustom = "custom"
+)
+
+// ThemeModes lists all popup theme modes
+var ThemeModes = []string{ThemeDark, ThemeLight, ThemeSystem, ThemeCustom}
+
+var hexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// ThemeSettings controls how the popup looks
+type ThemeSettings struct {
+	Mode       string  `json:"mode,omitempty"`       // dark (default), light, system or custom
+	Background string  `json:"background,omitempty"` // #RRGGBB, custom theme only
+	Foreground string  `json:"foreground,omitempty"` // #RRGGBB, custom theme only
+	Accent     string  `json:"accent,omitempty"`     // #RRGGBB, replaces the accent of any theme
+	FontScale  float64 `json:"font_scale,omitempty"` // text size relative to the default, 0 for unscaled
+}
+
+// ParseColor parses a #RRGGBB colour
+func ParseColor(s string) (color.NRGBA, error) {
+	if !hexColorPattern.MatchString(s) {
+		return color.NRGBA{}, fmt.Errorf("invalid colour: %s (use #RRGGBB)", s)
+	}
+	v, err := strconv.ParseUint(s[1:], 16, 32)
+	if err != nil {
+		return color.NRGBA{}, fmt.Errorf("invalid colour: %s (use #RRGGBB)", s)
+	}
+	return color.NRGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xFF}, nil
+}
+
+func (t ThemeSettings) validate() error {
+	if t.Mode != "" && !slices.Contains(ThemeModes, t.Mode) {
+		return fmt.Errorf("unknown theme: %s (available: %s)", t.Mode, strings.Join(ThemeModes, ", "))
+	}
+	if t.Mode == ThemeCustom {
+		if t.Background == "" || t.Foreground == "" {
+			return fmt.Errorf("the custom theme needs a background and a foreground colour")
+		}
+	} else if t.Background != "" || t.Foreground != "" {
+		return fmt.Errorf("background and foreground colours need the custom theme")
+	}
+	for _, c := range []string{t.Background, t.Foreground, t.Accent} {
+		if c == "" {
+			continue
+		}
+		if _, err := ParseColor(c); err != nil {
+			return err
+		}
+	}
+	if t.FontScale != 0 && (t.FontScale < 0.5 || t.FontScale > 3) {
+		return fmt.Errorf("invalid font scale: %g (must be between 0.5 and 3)", t.FontScale)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected color.NRGBA
+		wantErr  bool
+	}{
+		{"#2D2D30", color.NRGBA{0x2D, 0x2D, 0x30, 0xFF}, false},
+		{"#ff6b6b", color.NRGBA{0xFF, 0x6B, 0x6B, 0xFF}, false},
+		{"2D2D30", color.NRGBA{}, true},
+		{"#2D2D3", color.NRGBA{}, true},
+		{"#GGGGGG", color.NRGBA{}, true},
+		{"red", color.NRGBA{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseColor(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseColor(%s) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.expected {
+			t.Errorf("ParseColor(%s) = %+v, expected %+v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestValidateThemeSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings ThemeSettings
+		wantErr  bool
+	}{
+		{"default", ThemeSettings{}, false},
+		{"light", ThemeSettings{Mode: ThemeLight}, false},
+		{"system with accent", ThemeSettings{Mode: ThemeSystem, Accent: "#FF6B6B", FontScale: 1.25}, false},
+		{"custom", ThemeSettings{Mode: ThemeCustom, Background: "#FFF8E7", Foreground: "#332211"}, false},
+		{"unknown mode", ThemeSettings{Mode: "neon"}, true},
+		{"custom without colours", ThemeSettings{Mode: ThemeCustom, Background: "#FFF8E7"}, true},
+		{"colours without custom", ThemeSettings{Mode: ThemeDark, Background: "#FFF8E7"}, true},
+		{"invalid accent", ThemeSettings{Accent: "blue"}, true},
+		{"font scale too small", ThemeSettings{FontScale: 0.2}, true},
+		{"font scale too large", ThemeSettings{FontScale: 4}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// URL placeholders expanded when the reminder fires, e.g.
+// https://canteen.example.com/menu?date={date}&team={env:TEAM_CODE}
+const (
+	URLPlaceholderDate    = "date"     // 2026-10-16
+	URLPlaceholderWeekday = "weekday"  // friday
+	URLPlaceholderISOWeek = "iso_week" // 42
+	URLPlaceholderUser    = "user"     // the user's login name
+
+	urlEnvPrefix = "env:" // {env:NAME} is the environment variable NAME
+)
+
+var envNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// URLContext is what URL placeholders are expanded with
+type URLContext struct {
+	Now    time.Time
+	User   string
+	Getenv func(string) string // unset variables expand to nothing
+}
+
+// sampleURLContext expands templates when validating them, so a config doesn't
+// depend on the environment it is loaded in
+var sampleURLContext = URLContext{
+	Now:    time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC),
+	User:   "user",
+	Getenv: func(string) string { return "value" },
+}
+
+// ExpandURL replaces the placeholders in link. The values are escaped, so they
+// can't change the structure of the URL.
+func ExpandURL(link string, ctx URLContext) (string, error) {
+	var b strings.Builder
+	rest := link
+	for {
+		open := strings.IndexAny(rest, "{}")
+		if open == -1 {
+			b.WriteString(rest)
+			return b.String(), nil
+		}
+		if rest[open] == '}' {
+			return "", fmt.Errorf("unexpected '}' in %s", link)
+		}
+		end := strings.IndexAny(rest[open+1:], "{}")
+		if end == -1 || rest[open+1+end] != '}' {
+			return "", fmt.Errorf("unclosed placeholder in %s", link)
+		}
+		value, err := placeholderValue(rest[open+1:open+1+end], ctx)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(rest[:open])
+		b.WriteString(url.QueryEscape(value))
+		rest = rest[open+1+end+1:]
+	}
+}
+
+func placeholderValue(name string, ctx URLContext) (string, error) {
+	switch name {
+	case URLPlaceholderDate:
+		return ctx.Now.Format("2006-01-02"), nil
+	case URLPlaceholderWeekday:
+		return strings.ToLower(ctx.Now.Weekday().String()), nil
+	case URLPlaceholderISOWeek:
+		_, week := ctx.Now.ISOWeek()
+		return strconv.Itoa(week), nil
+	case URLPlaceholderUser:
+		return ctx.User, nil
+	}
+	if env, ok := strings.CutPrefix(name, urlEnvPrefix); ok {
+		if !envNameRegex.MatchString(env) {
+			return "", fmt.Errorf("invalid environment variable name: %s", env)
+		}
+		if ctx.Getenv == nil {
+			return "", nil
+		}
+		return ctx.Getenv(env), nil
+	}
+	return "", fmt.Errorf("unknown placeholder {%s} (use {%s}, {%s}, {%s}, {%s} or {%sNAME})", name,
+		URLPlaceholderDate, URLPlaceholderWeekday, URLPlaceholderISOWeek, URLPlaceholderUser, urlEnvPrefix)
+}
+
+// ExpandOrderURL expands the placeholders in link and checks that the result is an
+// http or https link, which an environment variable in the host can break
+func ExpandOrderURL(link string, ctx URLContext) (string, error) {
+	expanded, err := ExpandURL(link, ctx)
+	if err != nil {
+		return "", err
+	}
+	if err := checkWebURL(expanded); err != nil {
+		return "", fmt.Errorf("%w: %s", err, expanded)
+	}
+	return expanded, nil
+}
+
+// ValidateOrderURL checks that link is a well-formed URL template expanding to an
+// http or https link
+func ValidateOrderURL(link string) error {
+	expanded, err := ExpandURL(link, sampleURLContext)
+	if err != nil {
+		return err
+	}
+	return checkWebURL(expanded)
+}
+
+// checkWebURL checks that link is an http or https link with a host
+func checkWebURL(link string) error {
+	u, err := url.Parse(link)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %s", link)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("URL must be an http or https link")
+	}
+	if u.Host == "" {
+		return errors.New("URL must have a host")
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpandURL(t *testing.T) {
+	ctx := URLContext{
+		Now:  time.Date(2026, 10, 16, 16, 0, 0, 0, time.Local),
+		User: "kari",
+		Getenv: func(name string) string {
+			return map[string]string{"TEAM_CODE": "A&B 7"}[name]
+		},
+	}
+
+	tests := []struct {
+		link     string
+		expected string
+		wantErr  bool
+	}{
+		{"https://wolt.com", "https://wolt.com", false},
+		{"https://canteen.example.com/menu?date={date}", "https://canteen.example.com/menu?date=2026-10-16", false},
+		{"https://example.com/{weekday}/{iso_week}", "https://example.com/friday/42", false},
+		{"https://example.com/?user={user}", "https://example.com/?user=kari", false},
+		{"https://example.com/?team={env:TEAM_CODE}", "https://example.com/?team=A%26B+7", false},
+		{"https://example.com/?team={env:UNSET}", "https://example.com/?team=", false},
+		{"https://example.com/?date={date", "", true},
+		{"https://example.com/?date=date}", "", true},
+		{"https://example.com/?date={{date}}", "", true},
+		{"https://example.com/?x={tomorrow}", "", true},
+		{"https://example.com/?x={env:BAD-NAME}", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ExpandURL(tt.link, ctx)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ExpandURL(%s) error = %v, wantErr %v", tt.link, err, tt.wantErr)
+		}
+		if got != tt.expected {
+			t.Errorf("ExpandURL(%s) = %s, expected %s", tt.link, got, tt.expected)
+		}
+	}
+}
+
+func TestValidateOrderURL(t *testing.T) {
+	tests := []struct {
+		link    string
+		wantErr bool
+	}{
+		{"https://canteen.example.com/menu?date={date}&team={env:TEAM_CODE}", false},
+		{"https://{env:ORDER_HOST}/menu", false},
+		{"http://example.com/{user}", false},
+		{"https://example.com/{unknown}", true},
+		{"{env:ORDER_URL}", true},
+		{"ftp://example.com/{date}", true},
+		{"example.com", true},
+	}
+
+	for _, tt := range tests {
+		if err := ValidateOrderURL(tt.link); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateOrderURL(%s) error = %v, wantErr %v", tt.link, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Vendor is a place to order dinner from
+type Vendor struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	Icon string `json:"icon,omitempty"` // emoji shown before the name
+}
+
+// OrderVendors returns the vendors to order from. A site link that hasn't been
+// migrated yet is the only vendor.
+func (c *Config) OrderVendors() []Vendor {
+	if len(c.Vendors) == 0 && c.InstallOptions.SiteLink != "" {
+		return []Vendor{{Name: vendorNameFromURL(c.InstallOptions.SiteLink), URL: c.InstallOptions.SiteLink}}
+	}
+	return c.Vendors
+}
+
+// DefaultVendor returns the index of the vendor to preselect, the last one ordered from
+func (c *Config) DefaultVendor() int {
+	if i := c.vendorIndex(c.State.LastVendor); i != -1 {
+		return i
+	}
+	return 0
+}
+
+// FindVendor returns the vendor with the given name, ignoring case
+func (c *Config) FindVendor(name string) (Vendor, bool) {
+	if i := c.vendorIndex(name); i != -1 {
+		return c.OrderVendors()[i], true
+	}
+	return Vendor{}, false
+}
+
+// SetLastVendor remembers the vendor last ordered from as the default
+func (c *Config) SetLastVendor(name string) {
+	c.State.LastVendor = name
+}
+
+// AddVendor adds a vendor to order from
+func (c *Config) AddVendor(v Vendor) error {
+	c.migrateSiteLink()
+	vendors := append(slices.Clone(c.Vendors), v)
+	if err := validateVendors(vendors); err != nil {
+		return err
+	}
+	c.Vendors = vendors
+	return nil
+}
+
+// RemoveVendor removes a vendor. The last vendor can't be removed, as there would
+// be nowhere left to order from.
+func (c *Config) RemoveVendor(name string) error {
+	c.migrateSiteLink()
+	i := c.vendorIndex(name)
+	if i == -1 {
+		return fmt.Errorf("no vendor named %s", name)
+	}
+	if len(c.Vendors) == 1 {
+		return errors.New("can't remove the only vendor")
+	}
+	if strings.EqualFold(c.State.LastVendor, c.Vendors[i].Name) {
+		c.State.LastVendor = ""
+	}
+	c.Vendors = slices.Delete(slices.Clone(c.Vendors), i, i+1)
+	return nil
+}
+
+// SetOrderURL sets the URL of the first vendor, the one the installer asks for
+func (c *Config) SetOrderURL(link string) {
+	c.migrateSiteLink()
+	c.InstallOptions.SiteLink = ""
+	if len(c.Vendors) > 0 && c.Vendors[0].URL == link {
+		return
+	}
+	v := Vendor{Name: vendorNameFromURL(link), URL: link}
+	if len(c.Vendors) == 0 {
+		c.Vendors = []Vendor{v}
+		return
+	}
+	c.Vendors = slices.Clone(c.Vendors)
+	c.Vendors[0] = v
+}
+
+// migrateSiteLink turns the single site link of older configs into a vendor
+func (c *Config) migrateSiteLink() {
+	if len(c.Vendors) > 0 || c.InstallOptions.SiteLink == "" {
+		return
+	}
+	c.Vendors = c.OrderVendors()
+	c.InstallOptions.SiteLink = ""
+}
+
+func (c *Config) vendorIndex(name string) int {
+	if name == "" {
+		return -1
+	}
+	return slices.IndexFunc(c.OrderVendors(), func(v Vendor) bool {
+		return strings.EqualFold(v.Name, name)
+	})
+}
+
+// vendorNameFromURL names a vendor after the host of its URL, e.g. foodora.no
+func vendorNameFromURL(link string) string {
+	u, err := url.Parse(link)
+	if err != nil || u.Hostname() == "" {
+		return "Order"
+	}
+	return strings.TrimPrefix(u.Hostname(), "www.")
+}
+
+func validateVendors(vendors []Vendor) error {
+	for i, v := range vendors {
+		if strings.TrimSpace(v.Name) == "" {
+			return errors.New("vendor name must not be empty")
+		}
+		if slices.ContainsFunc(vendors[:i], func(other Vendor) bool { return strings.EqualFold(other.Name, v.Name) }) {
+			return fmt.Errorf("vendor %s is listed twice", v.Name)
+		}
+		if err := ValidateOrderURL(v.URL); err != nil {
+			return fmt.Errorf("invalid URL for vendor %s: %w", v.Name, err)
+		}
+	}
+	return nil
+}
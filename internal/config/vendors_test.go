@@ -0,0 +1,147 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLoadMigratesSiteLink(t *testing.T) {
+	cm := &ConfigManager{
+		configDir:  t.TempDir(),
+		configFile: "test_config.json",
+	}
+	data := `{"install_options": {"days": ["Monday"], "hour": "16:00", "sitelink": "https://www.foodora.no/"}, "paused_until": -1}`
+	if err := os.WriteFile(filepath.Join(cm.configDir, cm.configFile), []byte(data), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := cm.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	expected := []Vendor{{Name: "foodora.no", URL: "https://www.foodora.no/"}}
+	if !slices.Equal(cfg.Vendors, expected) {
+		t.Errorf("Expected vendors %v, got %v", expected, cfg.Vendors)
+	}
+	if cfg.InstallOptions.SiteLink != "" {
+		t.Errorf("Expected the site link to be migrated, got '%s'", cfg.InstallOptions.SiteLink)
+	}
+
+	// the migrated config loads again
+	if err := cm.Save(cfg); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+	loaded, err := cm.Load()
+	if err != nil {
+		t.Fatalf("Failed to load migrated config: %v", err)
+	}
+	if !slices.Equal(loaded.Vendors, expected) {
+		t.Errorf("Expected vendors %v after saving, got %v", expected, loaded.Vendors)
+	}
+}
+
+func TestOrderVendors(t *testing.T) {
+	cfg := newTestConfig("16:00")
+	expected := []Vendor{{Name: "example.com", URL: "https://example.com"}}
+	if got := cfg.OrderVendors(); !slices.Equal(got, expected) {
+		t.Errorf("Expected the site link as vendor, got %v", got)
+	}
+
+	cfg.InstallOptions.SiteLink = ""
+	cfg.Vendors = []Vendor{{Name: "Wolt", URL: "https://wolt.com"}, {Name: "Canteen", URL: "https://canteen.example.com"}}
+	if got := cfg.OrderVendors(); !slices.Equal(got, cfg.Vendors) {
+		t.Errorf("Expected the configured vendors, got %v", got)
+	}
+}
+
+func TestDefaultVendor(t *testing.T) {
+	cfg := newTestConfig("16:00")
+	cfg.Vendors = []Vendor{{Name: "Wolt", URL: "https://wolt.com"}, {Name: "Canteen", URL: "https://canteen.example.com"}}
+
+	if i := cfg.DefaultVendor(); i != 0 {
+		t.Errorf("Expected the first vendor without a last vendor, got %d", i)
+	}
+	cfg.SetLastVendor("canteen")
+	if i := cfg.DefaultVendor(); i != 1 {
+		t.Errorf("Expected the last vendor, got %d", i)
+	}
+	cfg.SetLastVendor("Foodora")
+	if i := cfg.DefaultVendor(); i != 0 {
+		t.Errorf("Expected the first vendor for an unknown last vendor, got %d", i)
+	}
+}
+
+func TestAddVendor(t *testing.T) {
+	cfg := newTestConfig("16:00")
+
+	if err := cfg.AddVendor(Vendor{Name: "Wolt", URL: "https://wolt.com", Icon: "🍔"}); err != nil {
+		t.Fatalf("Failed to add vendor: %v", err)
+	}
+	if len(cfg.Vendors) != 2 || cfg.Vendors[0].URL != "https://example.com" || cfg.InstallOptions.SiteLink != "" {
+		t.Errorf("Expected the site link to be kept as first vendor, got %v", cfg.Vendors)
+	}
+
+	tests := []struct {
+		name   string
+		vendor Vendor
+	}{
+		{"duplicate", Vendor{Name: "wolt", URL: "https://wolt.com"}},
+		{"no name", Vendor{Name: " ", URL: "https://wolt.com"}},
+		{"invalid URL", Vendor{Name: "Canteen", URL: "canteen.local"}},
+		{"other scheme", Vendor{Name: "Canteen", URL: "file:///menu.html"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := cfg.AddVendor(tt.vendor); err == nil {
+				t.Error("Expected an error")
+			}
+			if len(cfg.Vendors) != 2 {
+				t.Errorf("Expected the vendors to be unchanged, got %v", cfg.Vendors)
+			}
+		})
+	}
+}
+
+func TestRemoveVendor(t *testing.T) {
+	cfg := newTestConfig("16:00")
+	cfg.InstallOptions.SiteLink = ""
+	cfg.Vendors = []Vendor{{Name: "Wolt", URL: "https://wolt.com"}, {Name: "Canteen", URL: "https://canteen.example.com"}}
+	cfg.SetLastVendor("Canteen")
+
+	if err := cfg.RemoveVendor("Foodora"); err == nil {
+		t.Error("Expected an error for an unknown vendor")
+	}
+	if err := cfg.RemoveVendor("canteen"); err != nil {
+		t.Fatalf("Failed to remove vendor: %v", err)
+	}
+	if len(cfg.Vendors) != 1 || cfg.Vendors[0].Name != "Wolt" {
+		t.Errorf("Expected only Wolt to be left, got %v", cfg.Vendors)
+	}
+	if cfg.State.LastVendor != "" {
+		t.Errorf("Expected the removed last vendor to be forgotten, got '%s'", cfg.State.LastVendor)
+	}
+	if err := cfg.RemoveVendor("Wolt"); err == nil {
+		t.Error("Expected an error when removing the only vendor")
+	}
+}
+
+func TestSetOrderURL(t *testing.T) {
+	cfg := &Config{}
+	cfg.SetOrderURL("https://wolt.com")
+	if expected := []Vendor{{Name: "wolt.com", URL: "https://wolt.com"}}; !slices.Equal(cfg.Vendors, expected) {
+		t.Errorf("Expected %v, got %v", expected, cfg.Vendors)
+	}
+
+	cfg.Vendors = []Vendor{{Name: "Wolt", URL: "https://wolt.com", Icon: "🍔"}, {Name: "Canteen", URL: "https://canteen.example.com"}}
+	cfg.SetOrderURL("https://wolt.com")
+	if cfg.Vendors[0].Name != "Wolt" || cfg.Vendors[0].Icon != "🍔" {
+		t.Errorf("Expected an unchanged URL to keep the vendor, got %v", cfg.Vendors[0])
+	}
+
+	cfg.SetOrderURL("https://www.foodora.no")
+	if len(cfg.Vendors) != 2 || cfg.Vendors[0].Name != "foodora.no" || cfg.Vendors[1].Name != "Canteen" {
+		t.Errorf("Expected the first vendor to be replaced, got %v", cfg.Vendors)
+	}
+}
@@ -0,0 +1,132 @@
+package holidays
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Calendar combines the public holidays of a set of regions with user-added dates
+type Calendar struct {
+	regions []string
+	custom  []customDate
+	loc     *time.Location
+}
+
+// customDate is a user-added day off. Year 0 means it repeats every year.
+type customDate struct {
+	year  int
+	month time.Month
+	day   int
+	name  string
+}
+
+// NewCalendar creates a calendar for the given regions in the given location
+func NewCalendar(regionCodes []string, loc *time.Location) (*Calendar, error) {
+	c := &Calendar{loc: loc}
+	for _, region := range regionCodes {
+		if !IsSupported(region) {
+			return nil, fmt.Errorf("unsupported holiday region: %s (supported: %s)", region, strings.Join(Regions(), ", "))
+		}
+		c.regions = append(c.regions, strings.ToUpper(region))
+	}
+	return c, nil
+}
+
+// AddCustom adds a user-defined day off, either a single date (YYYY-MM-DD)
+// or a date repeating every year (MM-DD)
+func (c *Calendar) AddCustom(date, name string) error {
+	d, err := parseCustomDate(date)
+	if err != nil {
+		return err
+	}
+	d.name = name
+	if d.name == "" {
+		d.name = "Custom holiday"
+	}
+	c.custom = append(c.custom, d)
+	return nil
+}
+
+// ValidateCustomDate checks that date is YYYY-MM-DD or MM-DD
+func ValidateCustomDate(date string) error {
+	_, err := parseCustomDate(date)
+	return err
+}
+
+func parseCustomDate(date string) (customDate, error) {
+	if t, err := time.Parse("2006-01-02", date); err == nil {
+		return customDate{year: t.Year(), month: t.Month(), day: t.Day()}, nil
+	}
+	// parse with a leap year so 02-29 is accepted for recurring dates
+	if t, err := time.Parse("2006-01-02", "2000-"+date); err == nil {
+		return customDate{month: t.Month(), day: t.Day()}, nil
+	}
+	return customDate{}, fmt.Errorf("invalid holiday date: %s (expected YYYY-MM-DD or MM-DD)", date)
+}
+
+// ForYear returns all holidays in the calendar for the given year, sorted by date.
+// A day that is a holiday in several regions is only listed once.
+func (c *Calendar) ForYear(year int) []Holiday {
+	seen := map[string]bool{}
+	var days []Holiday
+	add := func(h Holiday) {
+		key := h.Date.Format("2006-01-02")
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		days = append(days, h)
+	}
+
+	for _, region := range c.regions {
+		regionDays, _ := ForYear(region, year, c.loc)
+		for _, h := range regionDays {
+			add(h)
+		}
+	}
+	for _, d := range c.custom {
+		if d.year != 0 && d.year != year {
+			continue
+		}
+		date := time.Date(year, d.month, d.day, 0, 0, 0, 0, c.loc)
+		if date.Month() != d.month {
+			// Feb 29 in a non-leap year
+			continue
+		}
+		add(Holiday{Date: date, Name: d.name, Region: "custom"})
+	}
+
+	sort.Slice(days, func(i, j int) bool { return days[i].Date.Before(days[j].Date) })
+	return days
+}
+
+// HolidayOn returns the holiday on the same calendar day as t, if any
+func (c *Calendar) HolidayOn(t time.Time) (Holiday, bool) {
+	t = t.In(c.loc)
+	for _, h := range c.ForYear(t.Year()) {
+		if h.Date.Month() == t.Month() && h.Date.Day() == t.Day() {
+			return h, true
+		}
+	}
+	return Holiday{}, false
+}
+
+// Next returns the first holiday on or after the day of `from` that matches,
+// looking up to two years ahead
+func (c *Calendar) Next(from time.Time, match func(Holiday) bool) (Holiday, bool) {
+	from = from.In(c.loc)
+	today := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, c.loc)
+	for year := from.Year(); year <= from.Year()+2; year++ {
+		for _, h := range c.ForYear(year) {
+			if h.Date.Before(today) {
+				continue
+			}
+			if match == nil || match(h) {
+				return h, true
+			}
+		}
+	}
+	return Holiday{}, false
+}
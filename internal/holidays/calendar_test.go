@@ -0,0 +1,129 @@
+package holidays
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendarHolidayOn(t *testing.T) {
+	cal, err := NewCalendar([]string{"NO"}, time.UTC)
+	if err != nil {
+		t.Fatalf("Failed to create calendar: %v", err)
+	}
+	if err := cal.AddCustom("2026-10-16", "Office closed"); err != nil {
+		t.Fatalf("Failed to add custom date: %v", err)
+	}
+	if err := cal.AddCustom("12-24", "Christmas Eve"); err != nil {
+		t.Fatalf("Failed to add custom date: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected string
+	}{
+		{"ascension day", time.Date(2026, 5, 14, 16, 0, 0, 0, time.UTC), "Ascension Day"},
+		{"whit monday", time.Date(2026, 5, 25, 9, 0, 0, 0, time.UTC), "Whit Monday"},
+		{"custom date", time.Date(2026, 10, 16, 16, 0, 0, 0, time.UTC), "Office closed"},
+		{"custom date other year", time.Date(2027, 10, 16, 16, 0, 0, 0, time.UTC), ""},
+		{"recurring custom date", time.Date(2030, 12, 24, 16, 0, 0, 0, time.UTC), "Christmas Eve"},
+		{"regular day", time.Date(2026, 5, 13, 16, 0, 0, 0, time.UTC), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, ok := cal.HolidayOn(tt.at)
+			if tt.expected == "" {
+				if ok {
+					t.Errorf("Expected no holiday, got %s", h.Name)
+				}
+				return
+			}
+			if !ok || h.Name != tt.expected {
+				t.Errorf("Expected %s, got %s (holiday: %v)", tt.expected, h.Name, ok)
+			}
+		})
+	}
+}
+
+func TestCalendarNext(t *testing.T) {
+	cal, err := NewCalendar([]string{"NO"}, time.UTC)
+	if err != nil {
+		t.Fatalf("Failed to create calendar: %v", err)
+	}
+
+	// first holiday on a Thursday after Easter 2026
+	from := time.Date(2026, 4, 10, 12, 0, 0, 0, time.UTC)
+	h, ok := cal.Next(from, func(h Holiday) bool { return h.Date.Weekday() == time.Thursday })
+	if !ok {
+		t.Fatal("Expected to find a holiday")
+	}
+	if h.Name != "Ascension Day" {
+		t.Errorf("Expected Ascension Day, got %s on %v", h.Name, h.Date)
+	}
+
+	// today counts
+	h, ok = cal.Next(time.Date(2026, 12, 25, 18, 0, 0, 0, time.UTC), nil)
+	if !ok || h.Name != "Christmas Day" {
+		t.Errorf("Expected Christmas Day, got %s", h.Name)
+	}
+
+	// wraps into next year
+	h, ok = cal.Next(time.Date(2026, 12, 27, 0, 0, 0, 0, time.UTC), nil)
+	if !ok || h.Date.Year() != 2027 || h.Name != "New Year's Day" {
+		t.Errorf("Expected New Year's Day 2027, got %s on %v", h.Name, h.Date)
+	}
+
+	empty, _ := NewCalendar(nil, time.UTC)
+	if _, ok := empty.Next(from, nil); ok {
+		t.Error("Expected no holidays in an empty calendar")
+	}
+}
+
+func TestCalendarDeduplicates(t *testing.T) {
+	cal, _ := NewCalendar([]string{"NO"}, time.UTC)
+	if err := cal.AddCustom("05-17", "Syttende mai"); err != nil {
+		t.Fatalf("Failed to add custom date: %v", err)
+	}
+
+	count := 0
+	for _, h := range cal.ForYear(2026) {
+		if h.Date.Month() == time.May && h.Date.Day() == 17 {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("Expected May 17 once, got %d", count)
+	}
+}
+
+func TestCustomDateValidation(t *testing.T) {
+	tests := []struct {
+		date     string
+		hasError bool
+	}{
+		{"2026-10-16", false},
+		{"12-24", false},
+		{"02-29", false},
+		{"2026-02-30", true},
+		{"13-01", true},
+		{"christmas", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			err := ValidateCustomDate(tt.date)
+			if tt.hasError && err == nil {
+				t.Errorf("Expected error for %s, but got none", tt.date)
+			}
+			if !tt.hasError && err != nil {
+				t.Errorf("Unexpected error for %s: %v", tt.date, err)
+			}
+		})
+	}
+
+	if _, err := NewCalendar([]string{"XX"}, time.UTC); err == nil {
+		t.Error("Expected error for unsupported region, but got none")
+	}
+}
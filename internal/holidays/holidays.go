@@ -0,0 +1,104 @@
+package holidays
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Holiday is a day off, at midnight in the calendar's location
+type Holiday struct {
+	Date   time.Time
+	Name   string
+	Region string // region code, or "custom" for user-added dates
+}
+
+// rule computes a holiday for a year, given that year's Easter Sunday
+type rule struct {
+	name string
+	date func(year int, easter time.Time) time.Time
+}
+
+func fixed(month time.Month, day int) func(int, time.Time) time.Time {
+	return func(year int, easter time.Time) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, easter.Location())
+	}
+}
+
+func fromEaster(days int) func(int, time.Time) time.Time {
+	return func(year int, easter time.Time) time.Time {
+		return easter.AddDate(0, 0, days)
+	}
+}
+
+// regions maps region codes to their public holidays
+var regions = map[string][]rule{
+	"NO": {
+		{"New Year's Day", fixed(time.January, 1)},
+		{"Maundy Thursday", fromEaster(-3)},
+		{"Good Friday", fromEaster(-2)},
+		{"Easter Sunday", fromEaster(0)},
+		{"Easter Monday", fromEaster(1)},
+		{"Labour Day", fixed(time.May, 1)},
+		{"Constitution Day", fixed(time.May, 17)},
+		{"Ascension Day", fromEaster(39)},
+		{"Whit Sunday", fromEaster(49)},
+		{"Whit Monday", fromEaster(50)},
+		{"Christmas Day", fixed(time.December, 25)},
+		{"Boxing Day", fixed(time.December, 26)},
+	},
+}
+
+// Regions returns the supported region codes
+func Regions() []string {
+	var codes []string
+	for code := range regions {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// IsSupported reports whether there is a holiday calendar for the region code
+func IsSupported(region string) bool {
+	_, ok := regions[strings.ToUpper(region)]
+	return ok
+}
+
+// Easter returns Easter Sunday for the given year (Gregorian calendar)
+func Easter(year int, loc *time.Location) time.Time {
+	// Anonymous Gregorian algorithm (Meeus/Jones/Butcher)
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, loc)
+}
+
+// ForYear returns the public holidays of a region in the given year, sorted by date
+func ForYear(region string, year int, loc *time.Location) ([]Holiday, error) {
+	code := strings.ToUpper(region)
+	rules, ok := regions[code]
+	if !ok {
+		return nil, fmt.Errorf("unsupported holiday region: %s", region)
+	}
+
+	easter := Easter(year, loc)
+	var days []Holiday
+	for _, r := range rules {
+		days = append(days, Holiday{Date: r.date(year, easter), Name: r.name, Region: code})
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Date.Before(days[j].Date) })
+	return days, nil
+}
@@ -0,0 +1,80 @@
+package holidays
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEaster(t *testing.T) {
+	tests := []struct {
+		year     int
+		expected time.Time
+	}{
+		{2000, time.Date(2000, 4, 23, 0, 0, 0, 0, time.UTC)},
+		{2019, time.Date(2019, 4, 21, 0, 0, 0, 0, time.UTC)},
+		{2024, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{2025, time.Date(2025, 4, 20, 0, 0, 0, 0, time.UTC)},
+		{2026, time.Date(2026, 4, 5, 0, 0, 0, 0, time.UTC)},
+		{2027, time.Date(2027, 3, 28, 0, 0, 0, 0, time.UTC)},
+		{2038, time.Date(2038, 4, 25, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected.Format("2006"), func(t *testing.T) {
+			result := Easter(tt.year, time.UTC)
+			if !result.Equal(tt.expected) {
+				t.Errorf("Expected Easter %d on %v, got %v", tt.year, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestForYearNorway(t *testing.T) {
+	days, err := ForYear("no", 2026, time.UTC)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"2026-01-01": "New Year's Day",
+		"2026-04-02": "Maundy Thursday",
+		"2026-04-03": "Good Friday",
+		"2026-04-05": "Easter Sunday",
+		"2026-04-06": "Easter Monday",
+		"2026-05-01": "Labour Day",
+		"2026-05-17": "Constitution Day",
+		"2026-05-14": "Ascension Day",
+		"2026-05-24": "Whit Sunday",
+		"2026-05-25": "Whit Monday",
+		"2026-12-25": "Christmas Day",
+		"2026-12-26": "Boxing Day",
+	}
+
+	if len(days) != len(expected) {
+		t.Fatalf("Expected %d holidays, got %d", len(expected), len(days))
+	}
+	for i, h := range days {
+		date := h.Date.Format("2006-01-02")
+		if name, ok := expected[date]; !ok || name != h.Name {
+			t.Errorf("Unexpected holiday %s on %s", h.Name, date)
+		}
+		if h.Region != "NO" {
+			t.Errorf("Expected region NO, got %s", h.Region)
+		}
+		if i > 0 && h.Date.Before(days[i-1].Date) {
+			t.Error("Expected holidays to be sorted by date")
+		}
+	}
+}
+
+func TestForYearUnsupported(t *testing.T) {
+	if _, err := ForYear("XX", 2026, time.UTC); err == nil {
+		t.Error("Expected error for unsupported region, but got none")
+	}
+	if IsSupported("XX") {
+		t.Error("Expected XX to be unsupported")
+	}
+	if !IsSupported("no") {
+		t.Error("Expected region codes to be case-insensitive")
+	}
+}
@@ -2,7 +2,6 @@ package installer
 
 import (
 	"fmt"
-	"net/url"
 	"regexp"
 	"strings"
 	"sultengutt/internal/config"
@@ -113,15 +112,10 @@ func RunInstaller(alreadyInstalled bool, prev config.InstallOptions) (config.Ins
 		huh.NewGroup(
 			huh.NewInput().
 				Title("Order URL").
-				Description("Where would you like to be redirected when clicking 'Order'?").
+				Description("Where would you like to be redirected when clicking 'Order'?\n"+
+					"May contain {date}, {weekday}, {iso_week}, {user} or {env:NAME}").
 				Value(&options.SiteLink).
-				Validate(func(t string) error {
-					_, err := url.Parse(t)
-					if err != nil {
-						return fmt.Errorf("URL is invalid")
-					}
-					return nil
-				}),
+				Validate(config.ValidateOrderURL),
 		),
 	).WithTheme(huh.ThemeDracula())
 
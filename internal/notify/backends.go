@@ -0,0 +1,33 @@
+package notify
+
+import (
+	"errors"
+	"os"
+	"sultengutt/internal/config"
+	"sultengutt/internal/popup"
+	"sultengutt/internal/popup/model"
+
+	"github.com/mattn/go-isatty"
+)
+
+func newPopupNotifier(config.NotifierSettings) (Notifier, error) {
+	return funcNotifier{config.NotifierPopup, func(m model.Model) (model.Result, error) {
+		if !popup.HasDisplay() {
+			return model.Result{Action: model.ActionClose}, errors.New("no display")
+		}
+		return popup.ShowPopup(m)
+	}}, nil
+}
+
+func newTerminalNotifier(config.NotifierSettings) (Notifier, error) {
+	return funcNotifier{config.NotifierTerminal, func(m model.Model) (model.Result, error) {
+		if !isatty.IsTerminal(os.Stdin.Fd()) {
+			return model.Result{Action: model.ActionClose}, errors.New("no terminal")
+		}
+		return popup.ShowTerminalReminder(m)
+	}}, nil
+}
+
+func newDesktopNotifier(config.NotifierSettings) (Notifier, error) {
+	return funcNotifier{config.NotifierNotification, popup.ShowNotification}, nil
+}
@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sultengutt/internal/config"
+	"sultengutt/internal/popup/model"
+)
+
+// commandNotifier runs a user-supplied shell command with the reminder in its environment
+type commandNotifier struct {
+	command string
+}
+
+func newCommandNotifier(s config.NotifierSettings) (Notifier, error) {
+	if strings.TrimSpace(s.Command) == "" {
+		return nil, errors.New("no command configured")
+	}
+	return &commandNotifier{command: s.Command}, nil
+}
+
+func (c *commandNotifier) Name() string {
+	return config.NotifierCommand
+}
+
+func (c *commandNotifier) Notify(m model.Model) (model.Result, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", c.command)
+	} else {
+		cmd = exec.Command("sh", "-c", c.command)
+	}
+	cmd.Env = append(os.Environ(),
+		"SULTENGUTT_TITLE="+m.Title,
+		"SULTENGUTT_MESSAGE="+m.Message,
+		"SULTENGUTT_MANTRA="+m.Mantra,
+		"SULTENGUTT_URL="+m.OrderURL,
+	)
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return model.Result{Action: model.ActionClose}, fmt.Errorf("command failed: %w: %s", err, msg)
+		}
+		return model.Result{Action: model.ActionClose}, fmt.Errorf("command failed: %w", err)
+	}
+	return model.Result{Action: model.ActionSent}, nil
+}
@@ -0,0 +1,53 @@
+package notify
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sultengutt/internal/config"
+	"sultengutt/internal/popup/model"
+	"testing"
+)
+
+func TestCommandNotifier(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	out := filepath.Join(t.TempDir(), "out")
+	n, err := newCommandNotifier(config.NotifierSettings{Command: `echo "$SULTENGUTT_TITLE|$SULTENGUTT_URL" > ` + out})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	result, err := n.Notify(testModel())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Action != model.ActionSent {
+		t.Errorf("Expected sent, got %s", result.Action)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("Failed to read command output: %v", err)
+	}
+	if got := strings.TrimSpace(string(data)); got != "Dinner time|https://example.com" {
+		t.Errorf("Expected the reminder in the environment, got '%s'", got)
+	}
+}
+
+func TestCommandNotifierErrors(t *testing.T) {
+	if _, err := newCommandNotifier(config.NotifierSettings{Command: "  "}); err == nil {
+		t.Error("Expected an error without a command")
+	}
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	n, _ := newCommandNotifier(config.NotifierSettings{Command: "echo broken >&2; exit 3"})
+	_, err := n.Notify(testModel())
+	if err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("Expected the command output in the error, got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sultengutt/internal/config"
+	"sultengutt/internal/popup/model"
+)
+
+// Notifier delivers a reminder and reports how it ended
+type Notifier interface {
+	Name() string
+	Notify(m model.Model) (model.Result, error)
+}
+
+// Outcome is how a delivered reminder ended and which notifier delivered it
+type Outcome struct {
+	Notifier string
+	model.Result
+}
+
+// Factory creates a notifier from the notifier settings
+type Factory func(s config.NotifierSettings) (Notifier, error)
+
+var registry = map[string]Factory{
+	config.NotifierPopup:        newPopupNotifier,
+	config.NotifierTerminal:     newTerminalNotifier,
+	config.NotifierNotification: newDesktopNotifier,
+	config.NotifierCommand:      newCommandNotifier,
+	config.NotifierWebhook:      newWebhookNotifier,
+}
+
+// Register adds a notifier backend, replacing any backend with the same name
+func Register(name string, factory Factory) {
+	registry[name] = factory
+}
+
+// Registered returns the names of all registered backends
+func Registered() []string {
+	var names []string
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Build creates the notifiers in the given order. Backends that are unknown or
+// can't be created are logged and left out.
+func Build(s config.NotifierSettings, order []string, logger *log.Logger) []Notifier {
+	var notifiers []Notifier
+	for _, name := range order {
+		factory, ok := registry[name]
+		if !ok {
+			logger.Printf("unknown notifier %s, skipping it", name)
+			continue
+		}
+		n, err := factory(s)
+		if err != nil {
+			logger.Printf("notifier %s is not available: %v", name, err)
+			continue
+		}
+		notifiers = append(notifiers, n)
+	}
+	return notifiers
+}
+
+// Deliver tries the notifiers in order until one of them delivers the reminder.
+// A notifier that fails before the user has answered, e.g. because there is no
+// display, falls back to the next one.
+func Deliver(notifiers []Notifier, m model.Model, logger *log.Logger) (Outcome, error) {
+	var failures []string
+	for _, n := range notifiers {
+		result, err := n.Notify(m)
+		if err != nil && !result.Action.Answered() {
+			logger.Printf("notifier %s failed: %v", n.Name(), err)
+			failures = append(failures, fmt.Sprintf("%s: %v", n.Name(), err))
+			continue
+		}
+		if err != nil {
+			logger.Printf("notifier %s: %v", n.Name(), err)
+		}
+		logger.Printf("reminder delivered by %s: %s", n.Name(), result.Action)
+		return Outcome{Notifier: n.Name(), Result: result}, nil
+	}
+
+	if len(failures) == 0 {
+		return Outcome{}, errors.New("no notifiers configured")
+	}
+	return Outcome{}, fmt.Errorf("no notifier could deliver the reminder (%s)", strings.Join(failures, "; "))
+}
+
+// funcNotifier adapts a function to the Notifier interface
+type funcNotifier struct {
+	name   string
+	notify func(m model.Model) (model.Result, error)
+}
+
+func (f funcNotifier) Name() string {
+	return f.name
+}
+
+func (f funcNotifier) Notify(m model.Model) (model.Result, error) {
+	return f.notify(m)
+}
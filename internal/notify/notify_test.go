@@ -0,0 +1,145 @@
+package notify
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"slices"
+	"strings"
+	"sultengutt/internal/config"
+	"sultengutt/internal/popup/model"
+	"testing"
+)
+
+type fakeNotifier struct {
+	name   string
+	action model.Action
+	err    error
+	called int
+}
+
+func (f *fakeNotifier) Name() string { return f.name }
+
+func (f *fakeNotifier) Notify(model.Model) (model.Result, error) {
+	f.called++
+	return model.Result{Action: f.action}, f.err
+}
+
+func testModel() model.Model {
+	return model.Model{
+		Title:    "Dinner time",
+		Emoji:    "🍕",
+		Message:  "Order now",
+		Mantra:   "Ship it",
+		OrderURL: "https://example.com",
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	tests := []struct {
+		name      string
+		notifiers []*fakeNotifier
+		expected  Outcome
+		wantErr   bool
+		called    []int
+	}{
+		{
+			name: "first notifier delivers",
+			notifiers: []*fakeNotifier{
+				{name: "popup", action: model.ActionOrder},
+				{name: "terminal", action: model.ActionSkip},
+			},
+			expected: Outcome{Notifier: "popup", Result: model.Result{Action: model.ActionOrder}},
+			called:   []int{1, 0},
+		},
+		{
+			name: "falls back when a notifier fails",
+			notifiers: []*fakeNotifier{
+				{name: "popup", action: model.ActionClose, err: errors.New("no display")},
+				{name: "terminal", action: model.ActionSnooze},
+			},
+			expected: Outcome{Notifier: "terminal", Result: model.Result{Action: model.ActionSnooze}},
+			called:   []int{1, 1},
+		},
+		{
+			name: "keeps the answer when a notifier fails after it",
+			notifiers: []*fakeNotifier{
+				{name: "notification", action: model.ActionOrder, err: errors.New("no browser")},
+				{name: "terminal", action: model.ActionSkip},
+			},
+			expected: Outcome{Notifier: "notification", Result: model.Result{Action: model.ActionOrder}},
+			called:   []int{1, 0},
+		},
+		{
+			name: "all notifiers fail",
+			notifiers: []*fakeNotifier{
+				{name: "popup", err: errors.New("no display")},
+				{name: "terminal", err: errors.New("no terminal")},
+			},
+			wantErr: true,
+			called:  []int{1, 1},
+		},
+		{
+			name:    "no notifiers",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var notifiers []Notifier
+			for _, n := range tt.notifiers {
+				notifiers = append(notifiers, n)
+			}
+			var logs bytes.Buffer
+
+			outcome, err := Deliver(notifiers, testModel(), log.New(&logs, "", 0))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Deliver() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if outcome != tt.expected {
+				t.Errorf("Expected outcome %+v, got %+v", tt.expected, outcome)
+			}
+			for i, n := range tt.notifiers {
+				if n.called != tt.called[i] {
+					t.Errorf("Expected %s to be called %d times, got %d", n.name, tt.called[i], n.called)
+				}
+				if n.err != nil && n.called > 0 && !strings.Contains(logs.String(), n.err.Error()) {
+					t.Errorf("Expected the %s failure to be logged, got:\n%s", n.name, logs.String())
+				}
+			}
+			if !tt.wantErr && !strings.Contains(logs.String(), "delivered by "+tt.expected.Notifier) {
+				t.Errorf("Expected the chosen notifier to be logged, got:\n%s", logs.String())
+			}
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+	fake := &fakeNotifier{name: "fake", action: model.ActionSent}
+	Register("fake", func(config.NotifierSettings) (Notifier, error) { return fake, nil })
+	defer delete(registry, "fake")
+
+	var logs bytes.Buffer
+	notifiers := Build(config.NotifierSettings{}, []string{"fake", "pigeon", config.NotifierCommand, config.NotifierPopup}, log.New(&logs, "", 0))
+
+	var names []string
+	for _, n := range notifiers {
+		names = append(names, n.Name())
+	}
+	// pigeon is unknown and the command notifier has no command
+	if !slices.Equal(names, []string{"fake", config.NotifierPopup}) {
+		t.Errorf("Expected [fake popup], got %v", names)
+	}
+	if !strings.Contains(logs.String(), "pigeon") || !strings.Contains(logs.String(), "command") {
+		t.Errorf("Expected the skipped notifiers to be logged, got:\n%s", logs.String())
+	}
+}
+
+func TestRegisteredCoversConfig(t *testing.T) {
+	for _, name := range config.Notifiers {
+		if !slices.Contains(Registered(), name) {
+			t.Errorf("Notifier %s is in the config but has no backend", name)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sultengutt/internal/config"
+	"sultengutt/internal/popup/model"
+	"time"
+)
+
+// webhookNotifier posts the reminder as JSON to a URL, e.g. a chat webhook
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+type webhookPayload struct {
+	Title   string `json:"title"`
+	Emoji   string `json:"emoji"`
+	Message string `json:"message"`
+	Mantra  string `json:"mantra"`
+	URL     string `json:"url"`
+	Text    string `json:"text"` // the whole reminder as one line, for Slack-style webhooks
+}
+
+func newWebhookNotifier(s config.NotifierSettings) (Notifier, error) {
+	if s.Webhook == "" {
+		return nil, errors.New("no webhook URL configured")
+	}
+	return &webhookNotifier{url: s.Webhook, client: &http.Client{Timeout: 10 * time.Second}}, nil
+}
+
+func (w *webhookNotifier) Name() string {
+	return config.NotifierWebhook
+}
+
+func (w *webhookNotifier) Notify(m model.Model) (model.Result, error) {
+	body, err := json.Marshal(webhookPayload{
+		Title:   m.Title,
+		Emoji:   m.Emoji,
+		Message: m.Message,
+		Mantra:  m.Mantra,
+		URL:     m.OrderURL,
+		Text:    fmt.Sprintf("%s %s %s %s", m.Emoji, m.Title, m.Message, m.OrderURL),
+	})
+	if err != nil {
+		return model.Result{Action: model.ActionClose}, fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	resp, err := w.client.Post(w.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return model.Result{Action: model.ActionClose}, fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return model.Result{Action: model.ActionClose}, fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return model.Result{Action: model.ActionSent}, nil
+}
@@ -0,0 +1,55 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sultengutt/internal/config"
+	"sultengutt/internal/popup/model"
+	"testing"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	var got webhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	n, err := newWebhookNotifier(config.NotifierSettings{Webhook: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+	result, err := n.Notify(testModel())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Action != model.ActionSent {
+		t.Errorf("Expected sent, got %s", result.Action)
+	}
+	if got.Title != "Dinner time" || got.URL != "https://example.com" || got.Mantra != "Ship it" || got.Text == "" {
+		t.Errorf("Unexpected payload %+v", got)
+	}
+}
+
+func TestWebhookNotifierErrors(t *testing.T) {
+	if _, err := newWebhookNotifier(config.NotifierSettings{}); err == nil {
+		t.Error("Expected an error without a URL")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	n, _ := newWebhookNotifier(config.NotifierSettings{Webhook: server.URL})
+	if _, err := n.Notify(testModel()); err == nil {
+		t.Error("Expected an error for a failing webhook")
+	}
+}
@@ -0,0 +1,105 @@
+package opener
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"sultengutt/internal/config"
+	"time"
+)
+
+// launchTimeout is how long a browser command gets to fail. Commands still running
+// by then are taken as a browser that was started in the foreground.
+const launchTimeout = 2 * time.Second
+
+// allowedSchemes are the only links opened, so a crafted link can't start programs
+// through other URL handlers
+var allowedSchemes = []string{"http", "https"}
+
+// Opener opens web pages in a browser
+type Opener struct {
+	command []string // browser command and arguments, the URL is appended
+	start   func(name string, args ...string) error
+}
+
+// New creates an opener for the browser settings, using the system browser unless
+// a browser command is configured
+func New(settings config.BrowserSettings) *Opener {
+	command := defaultCommand(runtime.GOOS)
+	if settings.Command != "" {
+		command = append([]string{settings.Command}, settings.Args...)
+	}
+	return &Opener{command: command, start: startCommand}
+}
+
+// Default creates an opener for the system browser
+func Default() *Opener {
+	return New(config.BrowserSettings{})
+}
+
+// Open opens rawURL in the browser. Waiting for the browser to fail can take up to
+// the launch timeout, so UIs call it off their UI thread.
+func (o *Opener) Open(rawURL string) error {
+	if err := CheckURL(rawURL); err != nil {
+		return err
+	}
+	args := append(slices.Clone(o.command[1:]), rawURL)
+	if err := o.start(o.command[0], args...); err != nil {
+		return fmt.Errorf("failed to open %s with %s: %w", rawURL, o.command[0], err)
+	}
+	return nil
+}
+
+// CheckURL checks that rawURL is a web link the opener will open
+func CheckURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if !slices.Contains(allowedSchemes, strings.ToLower(u.Scheme)) {
+		return fmt.Errorf("only http and https links can be opened: %s", rawURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("link has no host: %s", rawURL)
+	}
+	return nil
+}
+
+// defaultCommand returns the command opening links in the system browser on goos
+func defaultCommand(goos string) []string {
+	switch goos {
+	case "darwin":
+		return []string{"open"}
+	case "windows":
+		return []string{"rundll32", "url.dll,FileProtocolHandler"}
+	}
+	return []string{"xdg-open"}
+}
+
+// startCommand starts a command and reports it failing within the launch timeout
+func startCommand(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	select {
+	case err := <-done:
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() > 0 {
+			return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return err
+	case <-time.After(launchTimeout):
+		return nil
+	}
+}
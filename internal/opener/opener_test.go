@@ -0,0 +1,123 @@
+package opener
+
+import (
+	"errors"
+	"runtime"
+	"slices"
+	"strings"
+	"sultengutt/internal/config"
+	"testing"
+)
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"https://example.com/order", false},
+		{"http://example.com", false},
+		{"HTTPS://example.com", false},
+		{"file:///etc/passwd", true},
+		{"javascript:alert(1)", true},
+		{"ms-settings:", true},
+		{"www.example.com", true},
+		{"https://", true},
+		{"https://exa mple.com/%zz", true},
+	}
+
+	for _, tt := range tests {
+		err := CheckURL(tt.url)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CheckURL(%s) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
+
+func TestDefaultCommand(t *testing.T) {
+	tests := []struct {
+		goos     string
+		expected []string
+	}{
+		{"darwin", []string{"open"}},
+		{"windows", []string{"rundll32", "url.dll,FileProtocolHandler"}},
+		{"linux", []string{"xdg-open"}},
+		{"freebsd", []string{"xdg-open"}},
+	}
+
+	for _, tt := range tests {
+		if got := defaultCommand(tt.goos); !slices.Equal(got, tt.expected) {
+			t.Errorf("defaultCommand(%s) = %v, expected %v", tt.goos, got, tt.expected)
+		}
+	}
+}
+
+func TestOpen(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings config.BrowserSettings
+		expected []string
+	}{
+		{"system browser", config.BrowserSettings{}, append(defaultCommand(runtime.GOOS), "https://example.com")},
+		{"browser", config.BrowserSettings{Command: "firefox"}, []string{"firefox", "https://example.com"}},
+		{"profile", config.BrowserSettings{Command: "firefox", Args: []string{"-P", "work"}}, []string{"firefox", "-P", "work", "https://example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			o := New(tt.settings)
+			o.start = func(name string, args ...string) error {
+				got = append([]string{name}, args...)
+				return nil
+			}
+
+			if err := o.Open("https://example.com"); err != nil {
+				t.Fatalf("Failed to open: %v", err)
+			}
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestOpenRefusesOtherSchemes(t *testing.T) {
+	o := Default()
+	o.start = func(name string, args ...string) error {
+		t.Errorf("Expected nothing to be started, got %s %v", name, args)
+		return nil
+	}
+
+	if err := o.Open("file:///etc/passwd"); err == nil {
+		t.Error("Expected file links to be refused")
+	}
+}
+
+func TestOpenReportsFailure(t *testing.T) {
+	o := Default()
+	o.start = func(string, ...string) error {
+		return errors.New("exit status 3")
+	}
+
+	err := o.Open("https://example.com")
+	if err == nil || !strings.Contains(err.Error(), "exit status 3") {
+		t.Errorf("Expected the browser failure, got %v", err)
+	}
+}
+
+func TestStartCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	if err := startCommand("sh", "-c", "exit 0"); err != nil {
+		t.Errorf("Expected success, got %v", err)
+	}
+	err := startCommand("sh", "-c", "echo no browser >&2; exit 3")
+	if err == nil || !strings.Contains(err.Error(), "no browser") {
+		t.Errorf("Expected the error output, got %v", err)
+	}
+	if err := startCommand("sultengutt-no-such-browser"); err == nil {
+		t.Error("Expected an error for a missing command")
+	}
+}
@@ -0,0 +1,231 @@
+package gui
+
+import (
+	"strings"
+	"sultengutt/internal/popup/model"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/app"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// WindowSize is the size of the popup window
+var WindowSize = fyne.NewSize(460, 400)
+
+// Run displays the popup reminder and blocks until it is closed, returning what the user chose.
+// openURL is the platform's way of opening the order page in a browser.
+func Run(m model.Model, openURL func(string) error) model.Result {
+	myApp := app.New()
+	myApp.Settings().SetTheme(NewTheme(m.Theme))
+	window := myApp.NewWindow("Sultengutt")
+
+	// Clean, modern window size
+	window.Resize(WindowSize)
+
+	window.CenterOnScreen()
+
+	result := model.Result{Action: model.ActionClose}
+	opening := false
+	c := newContent(m, func(r model.Result) {
+		if r.Action != model.ActionOrder {
+			result = r
+			window.Close()
+			return
+		}
+		if opening {
+			return
+		}
+		opening = true
+		// starting the browser can take a while, so it must not block the UI thread
+		go func() {
+			err := openURL(m.OrderURLFor(r))
+			fyne.Do(func() {
+				opening = false
+				if err != nil {
+					dialog.ShowError(err, window)
+					return
+				}
+				result = r
+				window.Close()
+			})
+		}()
+	})
+	window.SetContent(c.object)
+	c.shortcuts.bind(window.Canvas())
+	// the primary button has the focus, so it is clear what Enter does
+	if c.primary != nil {
+		window.Canvas().Focus(c.primary)
+	}
+
+	// Auto-close after the timeout
+	if m.Timeout > 0 {
+		go func() {
+			time.Sleep(m.Timeout)
+			fyne.Do(func() {
+				result = model.Result{Action: model.ActionTimeout}
+				window.Close()
+			})
+		}()
+	}
+
+	window.ShowAndRun()
+	return result
+}
+
+// popupContent is the popup content with its keyboard shortcuts
+type popupContent struct {
+	object    fyne.CanvasObject
+	shortcuts *shortcuts
+	primary   fyne.Focusable // the button focused first
+}
+
+// NewContent builds the popup content for a model. onResult is called with the
+// choice the user made.
+func NewContent(m model.Model, onResult func(model.Result)) fyne.CanvasObject {
+	return newContent(m, onResult).object
+}
+
+// newContent builds the popup content and its shortcuts: Enter presses the primary
+// button, Escape skips and S snoozes for the selected duration. Fyne has no
+// accessibility API, so unlike the Windows script popup these widgets have no
+// accessible names for screen readers.
+func newContent(m model.Model, onResult func(model.Result)) popupContent {
+	keys := newShortcuts()
+	var primary fyne.Focusable
+	// Large emoji, or the configured image in its place
+	var emojiContainer *fyne.Container
+	if m.ImagePath != "" {
+		image := canvas.NewImageFromFile(m.ImagePath)
+		image.FillMode = canvas.ImageFillContain
+		image.SetMinSize(fyne.NewSize(64, 64))
+		emojiContainer = container.NewCenter(image)
+	} else {
+		emojiText := canvas.NewText(m.Emoji, theme.Color(theme.ColorNameForeground))
+		emojiText.TextSize = textSize(48)
+		emojiText.Alignment = fyne.TextAlignCenter
+		emojiContainer = container.NewCenter(emojiText)
+	}
+
+	// Clean, modern title
+	titleText := canvas.NewText(m.Title, theme.Color(theme.ColorNameForeground))
+	titleText.TextSize = textSize(24)
+	titleText.TextStyle = fyne.TextStyle{Bold: true}
+	titleText.Alignment = fyne.TextAlignCenter
+	titleContainer := container.NewCenter(titleText)
+
+	// Simple subtitle
+	subtitleText := canvas.NewText(m.Subtitle(), theme.Color(theme.ColorNamePlaceHolder))
+	subtitleText.TextSize = textSize(16)
+	subtitleText.Alignment = fyne.TextAlignCenter
+	subtitleContainer := container.NewCenter(subtitleText)
+
+	// Mantra section header
+	mantraHeaderText := canvas.NewText(m.MantraHeader, theme.Color(theme.ColorNamePlaceHolder))
+	mantraHeaderText.TextSize = textSize(14)
+	mantraHeaderText.Alignment = fyne.TextAlignCenter
+	mantraHeaderContainer := container.NewCenter(mantraHeaderText)
+
+	// Mantra text with quotes
+	mantraQuoteText := canvas.NewText("\""+m.Mantra+"\"", theme.Color(theme.ColorNameForeground))
+	mantraQuoteText.TextSize = textSize(18)
+	mantraQuoteText.TextStyle = fyne.TextStyle{Italic: true}
+	mantraQuoteText.Alignment = fyne.TextAlignCenter
+
+	// Wrap mantra in a container with max width for better line breaks
+	mantraContainer := container.NewCenter(
+		container.NewPadded(mantraQuoteText),
+	)
+
+	// Vendor picker when there are several places to order from
+	vendor := func() int { return m.DefaultVendor }
+	var vendorPicker fyne.CanvasObject
+	if len(m.Vendors) > 1 {
+		labels := make([]string, len(m.Vendors))
+		for i, v := range m.Vendors {
+			labels[i] = v.Label()
+		}
+		picker := newShortcutSelect(labels, keys)
+		picker.SetSelectedIndex(m.DefaultVendor)
+		vendor = picker.SelectedIndex
+		vendorPicker = picker
+	}
+
+	// Button container with equal spacing
+	buttonContainer := container.New(layout.NewGridLayoutWithColumns(max(len(m.Buttons), 1)))
+	for _, b := range m.Buttons {
+		action := b.Action
+		tapped := func() {
+			if action == model.ActionOrder {
+				onResult(m.OrderResult(vendor()))
+				return
+			}
+			onResult(model.Result{Action: action})
+		}
+		button := newShortcutButton(b.Label, tapped, keys)
+		if b.Primary {
+			button.Importance = widget.HighImportance
+			primary = button
+			keys.keys[fyne.KeyReturn] = tapped
+			keys.keys[fyne.KeyEnter] = tapped
+		} else if action == model.ActionSkip {
+			keys.keys[fyne.KeyEscape] = tapped
+		}
+		buttonContainer.Add(button)
+	}
+
+	// Clean vertical layout with proper spacing
+	content := container.NewVBox(
+		container.NewPadded(emojiContainer),
+		titleContainer,
+		container.NewPadded(subtitleContainer),
+		container.NewPadded(widget.NewSeparator()),
+		mantraHeaderContainer,
+		container.NewPadded(mantraContainer),
+		layout.NewSpacer(),
+	)
+	if vendorPicker != nil {
+		content.Add(container.NewPadded(vendorPicker))
+	}
+	content.Add(container.NewPadded(buttonContainer))
+	if snooze := newSnoozeRow(m.SnoozeOptions, onResult, keys); snooze != nil {
+		content.Add(container.NewPadded(snooze))
+	}
+
+	// Shortcut hints, so the shortcuts can be found without the mouse
+	hintText := canvas.NewText(strings.Join(m.ShortcutHints(), "   ·   "), theme.Color(theme.ColorNamePlaceHolder))
+	hintText.TextSize = textSize(12)
+	hintText.Alignment = fyne.TextAlignCenter
+	content.Add(container.NewCenter(hintText))
+
+	// Add padding around the entire content
+	return popupContent{object: container.NewPadded(content), shortcuts: keys, primary: primary}
+}
+
+// newSnoozeRow builds the snooze duration picker and button, or returns nil if the
+// reminder can't be snoozed
+func newSnoozeRow(options []time.Duration, onResult func(model.Result), keys *shortcuts) fyne.CanvasObject {
+	if len(options) == 0 {
+		return nil
+	}
+
+	labels := make([]string, len(options))
+	for i, d := range options {
+		labels[i] = model.SnoozeLabel(d)
+	}
+	picker := newShortcutSelect(labels, keys)
+	picker.SetSelectedIndex(0)
+
+	snooze := func() {
+		i := max(picker.SelectedIndex(), 0)
+		onResult(model.Result{Action: model.ActionSnooze, SnoozeFor: options[i]})
+	}
+	keys.runes['s'] = snooze
+	button := newShortcutButton("Snooze", snooze, keys)
+	return container.NewBorder(nil, nil, nil, button, picker)
+}
@@ -0,0 +1,271 @@
+package gui
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sultengutt/internal/popup/model"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+// walk calls fn for every object in the tree below obj
+func walk(obj fyne.CanvasObject, fn func(fyne.CanvasObject)) {
+	fn(obj)
+	if c, ok := obj.(*fyne.Container); ok {
+		for _, child := range c.Objects {
+			walk(child, fn)
+		}
+	}
+}
+
+func findButton(obj fyne.CanvasObject, label string) *shortcutButton {
+	var found *shortcutButton
+	walk(obj, func(o fyne.CanvasObject) {
+		if b, ok := o.(*shortcutButton); ok && b.Text == label {
+			found = b
+		}
+	})
+	return found
+}
+
+// asSelect returns the select widget o is, if it is one
+func asSelect(o fyne.CanvasObject) (*widget.Select, bool) {
+	switch s := o.(type) {
+	case *widget.Select:
+		return s, true
+	case *shortcutSelect:
+		return &s.Select, true
+	}
+	return nil, false
+}
+
+func texts(obj fyne.CanvasObject) []string {
+	var result []string
+	walk(obj, func(o fyne.CanvasObject) {
+		if t, ok := o.(*canvas.Text); ok {
+			result = append(result, t.Text)
+		}
+	})
+	return result
+}
+
+func testModel() model.Model {
+	return model.Model{
+		Title:        "Test Title",
+		Emoji:        "🍕",
+		Message:      "Test message",
+		MantraHeader: "Mantra header",
+		Mantra:       "Ship it",
+		Buttons: []model.Button{
+			{Label: "Skip", Action: model.ActionSkip},
+			{Label: "Order", Action: model.ActionOrder, Primary: true},
+		},
+		OrderURL: "https://example.com",
+	}
+}
+
+func TestNewContentRendersModel(t *testing.T) {
+	test.NewTempApp(t)
+
+	content := NewContent(testModel(), func(model.Result) {})
+
+	expected := map[string]bool{
+		"🍕":             false,
+		"Test Title":    false,
+		"Test message":  false,
+		"Mantra header": false,
+		"\"Ship it\"":   false,
+	}
+	for _, text := range texts(content) {
+		if _, ok := expected[text]; ok {
+			expected[text] = true
+		}
+	}
+	for text, found := range expected {
+		if !found {
+			t.Errorf("Expected popup to show '%s'", text)
+		}
+	}
+
+	order := findButton(content, "Order")
+	if order == nil {
+		t.Fatal("Expected an Order button")
+	}
+	if order.Importance != widget.HighImportance {
+		t.Error("Expected the primary button to have high importance")
+	}
+	if skip := findButton(content, "Skip"); skip == nil || skip.Importance == widget.HighImportance {
+		t.Error("Expected a regular Skip button")
+	}
+}
+
+func TestNewContentButtons(t *testing.T) {
+	test.NewTempApp(t)
+
+	tests := []struct {
+		label    string
+		expected model.Action
+	}{
+		{"Order", model.ActionOrder},
+		{"Skip", model.ActionSkip},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			var got []model.Result
+			content := NewContent(testModel(), func(r model.Result) {
+				got = append(got, r)
+			})
+
+			test.Tap(findButton(content, tt.label))
+
+			if len(got) != 1 || got[0].Action != tt.expected {
+				t.Errorf("Expected action %s, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewContentSnooze(t *testing.T) {
+	test.NewTempApp(t)
+
+	m := testModel()
+	m.SnoozeOptions = []time.Duration{10 * time.Minute, time.Hour}
+
+	var got []model.Result
+	content := NewContent(m, func(r model.Result) {
+		got = append(got, r)
+	})
+
+	var picker *widget.Select
+	walk(content, func(o fyne.CanvasObject) {
+		if s, ok := asSelect(o); ok {
+			picker = s
+		}
+	})
+	if picker == nil {
+		t.Fatal("Expected a snooze duration picker")
+	}
+	if picker.Selected != "Snooze 10 min" {
+		t.Errorf("Expected the first option to be selected, got '%s'", picker.Selected)
+	}
+
+	picker.SetSelected("Snooze 1 hour")
+	test.Tap(findButton(content, "Snooze"))
+
+	expected := model.Result{Action: model.ActionSnooze, SnoozeFor: time.Hour}
+	if len(got) != 1 || got[0] != expected {
+		t.Errorf("Expected %+v, got %v", expected, got)
+	}
+}
+
+func TestNewContentWithoutSnooze(t *testing.T) {
+	test.NewTempApp(t)
+
+	content := NewContent(testModel(), func(model.Result) {})
+	if findButton(content, "Snooze") != nil {
+		t.Error("Expected no snooze button without snooze options")
+	}
+}
+
+func TestNewContentImage(t *testing.T) {
+	test.NewTempApp(t)
+
+	path := filepath.Join(t.TempDir(), "logo.png")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create image: %v", err)
+	}
+	if err := png.Encode(f, image.NewRGBA(image.Rect(0, 0, 8, 8))); err != nil {
+		t.Fatalf("Failed to write image: %v", err)
+	}
+	f.Close()
+
+	m := testModel()
+	m.ImagePath = path
+	content := NewContent(m, func(model.Result) {})
+
+	var img *canvas.Image
+	walk(content, func(o fyne.CanvasObject) {
+		if i, ok := o.(*canvas.Image); ok {
+			img = i
+		}
+	})
+	if img == nil || img.File != path {
+		t.Fatalf("Expected the popup to show %s", path)
+	}
+	for _, text := range texts(content) {
+		if text == m.Emoji {
+			t.Error("Expected the image to replace the emoji")
+		}
+	}
+}
+
+func TestNewContentVendorPicker(t *testing.T) {
+	test.NewTempApp(t)
+
+	m := testModel()
+	m.Vendors = []model.Vendor{
+		{Name: "Wolt", URL: "https://wolt.com", Icon: "🍔"},
+		{Name: "Canteen", URL: "https://canteen.example.com"},
+	}
+	m.DefaultVendor = 1
+
+	var got []model.Result
+	content := NewContent(m, func(r model.Result) {
+		got = append(got, r)
+	})
+
+	var picker *widget.Select
+	walk(content, func(o fyne.CanvasObject) {
+		if s, ok := asSelect(o); ok && len(s.Options) == 2 && s.Options[0] == "🍔 Wolt" {
+			picker = s
+		}
+	})
+	if picker == nil {
+		t.Fatal("Expected a vendor picker")
+	}
+	if picker.Selected != "Canteen" {
+		t.Errorf("Expected the default vendor to be selected, got '%s'", picker.Selected)
+	}
+
+	picker.SetSelected("🍔 Wolt")
+	test.Tap(findButton(content, "Order"))
+
+	expected := model.Result{Action: model.ActionOrder, Vendor: "Wolt"}
+	if len(got) != 1 || got[0] != expected {
+		t.Errorf("Expected %+v, got %v", expected, got)
+	}
+	if url := m.OrderURLFor(got[0]); url != "https://wolt.com" {
+		t.Errorf("Expected the Wolt URL, got '%s'", url)
+	}
+}
+
+func TestNewContentSingleVendor(t *testing.T) {
+	test.NewTempApp(t)
+
+	m := testModel()
+	m.Vendors = []model.Vendor{{Name: "Wolt", URL: "https://wolt.com"}}
+
+	var got []model.Result
+	content := NewContent(m, func(r model.Result) {
+		got = append(got, r)
+	})
+	walk(content, func(o fyne.CanvasObject) {
+		if _, ok := asSelect(o); ok {
+			t.Error("Expected no vendor picker for a single vendor")
+		}
+	})
+
+	test.Tap(findButton(content, "Order"))
+	if len(got) != 1 || got[0].Vendor != "Wolt" {
+		t.Errorf("Expected an order from Wolt, got %v", got)
+	}
+}
@@ -0,0 +1,25 @@
+package gui
+
+import (
+	"image"
+	"sultengutt/internal/popup/model"
+
+	"fyne.io/fyne/v2/test"
+)
+
+// Screenshot renders the popup for m with Fyne's software test driver, so it works
+// without a display or GPU. There is no system to follow, so a theme following the
+// system is drawn light.
+func Screenshot(m model.Model) image.Image {
+	m.Theme = m.Theme.Resolve(false)
+	app := test.NewApp()
+	defer app.Quit()
+	app.Settings().SetTheme(NewTheme(m.Theme))
+
+	content := NewContent(m, func(model.Result) {})
+	window := test.NewWindow(content)
+	defer window.Close()
+	// like a real window, it grows to fit larger text
+	window.Resize(WindowSize.Max(content.MinSize()))
+	return window.Canvas().Capture()
+}
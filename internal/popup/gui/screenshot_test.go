@@ -0,0 +1,40 @@
+package gui
+
+import (
+	"sultengutt/internal/popup/model"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+)
+
+func TestScreenshot(t *testing.T) {
+	tests := []struct {
+		name  string
+		theme model.Theme
+	}{
+		{"dark", model.Theme{Mode: model.ThemeDark}},
+		{"light", model.Theme{Mode: model.ThemeLight}},
+		{"system", model.Theme{Mode: model.ThemeSystem}},
+		{"large_text", model.Theme{Mode: model.ThemeDark, FontScale: 1.5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testModel()
+			m.SnoozeOptions = []time.Duration{10 * time.Minute, 30 * time.Minute}
+			m.Theme = tt.theme
+
+			img := Screenshot(m)
+			if size := img.Bounds().Size(); size.X < int(WindowSize.Width) || size.Y < int(WindowSize.Height) {
+				t.Errorf("Expected at least a %vx%v screenshot, got %v", WindowSize.Width, WindowSize.Height, size)
+			}
+			golden := "screenshot_" + tt.name + ".png"
+			if tt.name == "system" {
+				// without a system to follow the light theme is drawn
+				golden = "screenshot_light.png"
+			}
+			test.AssertImageMatches(t, golden, img)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package gui
+
+import (
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// shortcuts are the popup's keyboard shortcuts, e.g. Enter to order. They work on
+// the canvas and while one of the popup's widgets has the focus.
+type shortcuts struct {
+	keys  map[fyne.KeyName]func()
+	runes map[rune]func()
+}
+
+func newShortcuts() *shortcuts {
+	return &shortcuts{keys: make(map[fyne.KeyName]func()), runes: make(map[rune]func())}
+}
+
+// typedKey runs the shortcut for a key, reporting whether there was one
+func (s *shortcuts) typedKey(ev *fyne.KeyEvent) bool {
+	if fn, ok := s.keys[ev.Name]; ok {
+		fn()
+		return true
+	}
+	return false
+}
+
+// typedRune runs the shortcut for a letter, ignoring case
+func (s *shortcuts) typedRune(r rune) bool {
+	if fn, ok := s.runes[unicode.ToLower(r)]; ok {
+		fn()
+		return true
+	}
+	return false
+}
+
+// bind makes the shortcuts work while no widget has the focus
+func (s *shortcuts) bind(c fyne.Canvas) {
+	c.SetOnTypedKey(func(ev *fyne.KeyEvent) { s.typedKey(ev) })
+	c.SetOnTypedRune(func(r rune) { s.typedRune(r) })
+}
+
+// shortcutButton is a button passing the popup's shortcuts on while it has the focus.
+// Space still taps the focused button.
+type shortcutButton struct {
+	widget.Button
+	shortcuts *shortcuts
+}
+
+func newShortcutButton(label string, tapped func(), s *shortcuts) *shortcutButton {
+	b := &shortcutButton{shortcuts: s}
+	b.Text = label
+	b.OnTapped = tapped
+	b.ExtendBaseWidget(b)
+	return b
+}
+
+func (b *shortcutButton) TypedKey(ev *fyne.KeyEvent) {
+	if !b.shortcuts.typedKey(ev) {
+		b.Button.TypedKey(ev)
+	}
+}
+
+func (b *shortcutButton) TypedRune(r rune) {
+	b.shortcuts.typedRune(r)
+}
+
+// shortcutSelect is a select passing the popup's shortcuts on while it has the focus.
+// The arrow keys and space still change the selection.
+type shortcutSelect struct {
+	widget.Select
+	shortcuts *shortcuts
+}
+
+func newShortcutSelect(options []string, s *shortcuts) *shortcutSelect {
+	sel := &shortcutSelect{shortcuts: s}
+	sel.Options = options
+	sel.ExtendBaseWidget(sel)
+	return sel
+}
+
+func (s *shortcutSelect) TypedKey(ev *fyne.KeyEvent) {
+	if !s.shortcuts.typedKey(ev) {
+		s.Select.TypedKey(ev)
+	}
+}
+
+func (s *shortcutSelect) TypedRune(r rune) {
+	s.shortcuts.typedRune(r)
+}
@@ -0,0 +1,114 @@
+package gui
+
+import (
+	"sultengutt/internal/popup/model"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestShortcuts(t *testing.T) {
+	tests := []struct {
+		name     string
+		typeKeys func(c fyne.Canvas)
+		expected model.Result
+	}{
+		{"enter", func(c fyne.Canvas) { c.OnTypedKey()(&fyne.KeyEvent{Name: fyne.KeyReturn}) }, model.Result{Action: model.ActionOrder}},
+		{"keypad enter", func(c fyne.Canvas) { c.OnTypedKey()(&fyne.KeyEvent{Name: fyne.KeyEnter}) }, model.Result{Action: model.ActionOrder}},
+		{"escape", func(c fyne.Canvas) { c.OnTypedKey()(&fyne.KeyEvent{Name: fyne.KeyEscape}) }, model.Result{Action: model.ActionSkip}},
+		{"s", func(c fyne.Canvas) { test.TypeOnCanvas(c, "s") }, model.Result{Action: model.ActionSnooze, SnoozeFor: 10 * time.Minute}},
+		{"capital s", func(c fyne.Canvas) { test.TypeOnCanvas(c, "S") }, model.Result{Action: model.ActionSnooze, SnoozeFor: 10 * time.Minute}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.NewTempApp(t)
+			m := testModel()
+			m.SnoozeOptions = []time.Duration{10 * time.Minute, time.Hour}
+
+			var got []model.Result
+			c := newContent(m, func(r model.Result) { got = append(got, r) })
+			window := test.NewTempWindow(t, c.object)
+			c.shortcuts.bind(window.Canvas())
+
+			tt.typeKeys(window.Canvas())
+			if len(got) != 1 || got[0] != tt.expected {
+				t.Errorf("Expected %+v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestShortcutsWhileFocused(t *testing.T) {
+	test.NewTempApp(t)
+	m := testModel()
+	m.Vendors = []model.Vendor{
+		{Name: "Wolt", URL: "https://wolt.com"},
+		{Name: "Foodora", URL: "https://foodora.no"},
+	}
+	m.SnoozeOptions = []time.Duration{10 * time.Minute, time.Hour}
+
+	var got []model.Result
+	c := newContent(m, func(r model.Result) { got = append(got, r) })
+	window := test.NewTempWindow(t, c.object)
+	c.shortcuts.bind(window.Canvas())
+
+	if c.primary == nil || c.primary != fyne.Focusable(findButton(c.object, "Order")) {
+		t.Fatal("Expected the order button to be focused first")
+	}
+
+	// the arrow keys still change the vendor while the picker has the focus
+	var vendorPicker, snoozePicker *shortcutSelect
+	walk(c.object, func(o fyne.CanvasObject) {
+		if s, ok := o.(*shortcutSelect); ok {
+			if s.Options[0] == "Wolt" {
+				vendorPicker = s
+			} else {
+				snoozePicker = s
+			}
+		}
+	})
+	window.Canvas().Focus(vendorPicker)
+	vendorPicker.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	vendorPicker.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	if len(got) != 1 || got[0] != (model.Result{Action: model.ActionOrder, Vendor: "Foodora"}) {
+		t.Errorf("Expected an order from the picked vendor, got %v", got)
+	}
+
+	window.Canvas().Focus(snoozePicker)
+	snoozePicker.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	snoozePicker.TypedRune('s')
+	if len(got) != 2 || got[1] != (model.Result{Action: model.ActionSnooze, SnoozeFor: time.Hour}) {
+		t.Errorf("Expected a snooze for the picked duration, got %v", got)
+	}
+
+	// space taps the focused button rather than the primary one
+	skip := findButton(c.object, "Skip")
+	window.Canvas().Focus(skip)
+	skip.TypedKey(&fyne.KeyEvent{Name: fyne.KeySpace})
+	if len(got) != 3 || got[2].Action != model.ActionSkip {
+		t.Errorf("Expected space to skip, got %v", got)
+	}
+}
+
+func TestShortcutsWithoutSnooze(t *testing.T) {
+	test.NewTempApp(t)
+
+	var got []model.Result
+	c := newContent(testModel(), func(r model.Result) { got = append(got, r) })
+	window := test.NewTempWindow(t, c.object)
+	c.shortcuts.bind(window.Canvas())
+
+	test.TypeOnCanvas(window.Canvas(), "s")
+	if len(got) != 0 {
+		t.Errorf("Expected S to do nothing when snoozing is turned off, got %v", got)
+	}
+	for _, text := range texts(c.object) {
+		if text == "Esc: Skip   ·   Enter: Order" {
+			return
+		}
+	}
+	t.Errorf("Expected the shortcut hints without snooze, got %v", texts(c.object))
+}
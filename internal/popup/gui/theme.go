@@ -0,0 +1,81 @@
+package gui
+
+import (
+	"image/color"
+	"sultengutt/internal/popup/model"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// baseTextSize is the popup's body text size before scaling
+const baseTextSize = 16
+
+// Theme draws the popup with the colours and text scale of a popup theme, on top
+// of Fyne's default theme
+type Theme struct {
+	theme model.Theme
+}
+
+// NewTheme creates the Fyne theme for t. A theme following the system is resolved
+// with the variant Fyne reports for the system.
+func NewTheme(t model.Theme) *Theme {
+	return &Theme{theme: t}
+}
+
+func (t *Theme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+	resolved := t.theme.Resolve(variant == theme.VariantDark)
+	palette := resolved.Palette()
+	switch name {
+	case theme.ColorNameBackground, theme.ColorNameOverlayBackground, theme.ColorNameMenuBackground:
+		return palette.Background
+	case theme.ColorNameForeground:
+		return palette.Foreground
+	case theme.ColorNamePlaceHolder:
+		return palette.Muted
+	case theme.ColorNameButton, theme.ColorNameInputBackground, theme.ColorNameSeparator:
+		return palette.Surface
+	case theme.ColorNamePrimary:
+		return palette.Accent
+	case theme.ColorNameFocus:
+		// blended over the focused button, so it stands out on the accent as well
+		focus := palette.Foreground
+		focus.A = 0x66
+		return focus
+	case theme.ColorNameForegroundOnPrimary:
+		return palette.OnAccent()
+	}
+
+	// everything else follows the brightness of the palette
+	if resolved.Dark() {
+		return theme.DefaultTheme().Color(name, theme.VariantDark)
+	}
+	return theme.DefaultTheme().Color(name, theme.VariantLight)
+}
+
+func (t *Theme) Font(style fyne.TextStyle) fyne.Resource {
+	return theme.DefaultTheme().Font(style)
+}
+
+func (t *Theme) Icon(name fyne.ThemeIconName) fyne.Resource {
+	return theme.DefaultTheme().Icon(name)
+}
+
+func (t *Theme) Size(name fyne.ThemeSizeName) float32 {
+	scale := t.theme.Scale()
+	switch name {
+	case theme.SizeNameText:
+		return baseTextSize * scale
+	case theme.SizeNameHeadingText:
+		return 24 * scale
+	case theme.SizeNameSubHeadingText:
+		return 18 * scale
+	default:
+		return theme.DefaultTheme().Size(name)
+	}
+}
+
+// textSize scales a text size of the popup layout with the current theme
+func textSize(size float32) float32 {
+	return size * theme.TextSize() / baseTextSize
+}
@@ -0,0 +1,118 @@
+package gui
+
+import (
+	"image/color"
+	"sultengutt/internal/popup/model"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+)
+
+func TestThemeColors(t *testing.T) {
+	accent := color.NRGBA{0xFF, 0x6B, 0x6B, 0xFF}
+	custom := model.NewPalette(color.NRGBA{0xFF, 0xF8, 0xE7, 0xFF}, color.NRGBA{0x33, 0x22, 0x11, 0xFF})
+
+	tests := []struct {
+		name     string
+		theme    model.Theme
+		variant  fyne.ThemeVariant
+		expected model.Palette
+	}{
+		{"dark", model.Theme{Mode: model.ThemeDark}, theme.VariantLight, model.DarkPalette},
+		{"light", model.Theme{Mode: model.ThemeLight}, theme.VariantDark, model.LightPalette},
+		{"system dark", model.Theme{Mode: model.ThemeSystem}, theme.VariantDark, model.DarkPalette},
+		{"system light", model.Theme{Mode: model.ThemeSystem}, theme.VariantLight, model.LightPalette},
+		{"custom", model.Theme{Mode: model.ThemeCustom, Custom: custom, Accent: accent}, theme.VariantDark, custom},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := NewTheme(tt.theme)
+			expected := tt.expected
+			if tt.theme.Accent.A != 0 {
+				expected.Accent = tt.theme.Accent
+			}
+
+			colors := map[fyne.ThemeColorName]color.Color{
+				theme.ColorNameBackground:  expected.Background,
+				theme.ColorNameForeground:  expected.Foreground,
+				theme.ColorNamePlaceHolder: expected.Muted,
+				theme.ColorNameButton:      expected.Surface,
+				theme.ColorNamePrimary:     expected.Accent,
+			}
+			for name, c := range colors {
+				if got := th.Color(name, tt.variant); got != c {
+					t.Errorf("Expected %s to be %+v, got %+v", name, c, got)
+				}
+			}
+		})
+	}
+}
+
+func TestThemeFontScale(t *testing.T) {
+	th := NewTheme(model.Theme{Mode: model.ThemeDark, FontScale: 1.5})
+
+	if size := th.Size(theme.SizeNameText); size != 24 {
+		t.Errorf("Expected text size 24, got %v", size)
+	}
+	if size := th.Size(theme.SizeNameHeadingText); size != 36 {
+		t.Errorf("Expected heading size 36, got %v", size)
+	}
+	if size := th.Size(theme.SizeNamePadding); size != theme.DefaultTheme().Size(theme.SizeNamePadding) {
+		t.Errorf("Expected padding to be unscaled, got %v", size)
+	}
+}
+
+func TestNewContentUsesTheme(t *testing.T) {
+	palette := model.NewPalette(color.NRGBA{0xFF, 0xF8, 0xE7, 0xFF}, color.NRGBA{0x33, 0x22, 0x11, 0xFF})
+	m := testModel()
+	m.Theme = model.Theme{Mode: model.ThemeCustom, Custom: palette, FontScale: 2}
+
+	app := test.NewTempApp(t)
+	app.Settings().SetTheme(NewTheme(m.Theme))
+
+	content := NewContent(m, func(model.Result) {})
+	walk(content, func(o fyne.CanvasObject) {
+		text, ok := o.(*canvas.Text)
+		if !ok {
+			return
+		}
+		switch text.Text {
+		case m.Title:
+			if text.Color != palette.Foreground || text.TextSize != 48 {
+				t.Errorf("Expected the title in %+v at size 48, got %+v at %v", palette.Foreground, text.Color, text.TextSize)
+			}
+		case m.Message:
+			if text.Color != palette.Muted || text.TextSize != 32 {
+				t.Errorf("Expected the message in %+v at size 32, got %+v at %v", palette.Muted, text.Color, text.TextSize)
+			}
+		}
+	})
+
+	// the window is drawn in the theme's background
+	window := test.NewWindow(content)
+	defer window.Close()
+	pixel := window.Canvas().Capture().At(1, 1)
+	r, g, b, _ := pixel.RGBA()
+	er, eg, eb, _ := palette.Background.RGBA()
+	if r>>8 != er>>8 || g>>8 != eg>>8 || b>>8 != eb>>8 {
+		t.Errorf("Expected the background %+v, got %+v", palette.Background, pixel)
+	}
+}
+
+func TestThemeFocusStandsOut(t *testing.T) {
+	for _, mode := range []model.ThemeMode{model.ThemeDark, model.ThemeLight} {
+		th := NewTheme(model.Theme{Mode: mode})
+		palette := model.Theme{Mode: mode}.Palette()
+		focus, ok := th.Color(theme.ColorNameFocus, theme.VariantDark).(color.NRGBA)
+		if !ok || focus.A == 0 || focus.A == 0xFF {
+			t.Errorf("%s: expected a translucent focus colour, got %+v", mode, focus)
+		}
+		if focus.R != palette.Foreground.R || focus.G != palette.Foreground.G || focus.B != palette.Foreground.B {
+			t.Errorf("%s: expected the focus to be drawn in the foreground, got %+v", mode, focus)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package model
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Action is what the user chose to do in the popup, or how it ended without a choice
+type Action string
+
+const (
+	ActionOrder   Action = "order"
+	ActionSkip    Action = "skip"
+	ActionSnooze  Action = "snooze"
+	ActionTimeout Action = "timeout" // nobody answered before the timeout
+	ActionClose   Action = "close"   // closed without choosing
+	ActionSent    Action = "sent"    // delivered somewhere the user can't answer from
+)
+
+// Answered reports whether the user made a choice
+func (a Action) Answered() bool {
+	return a == ActionOrder || a == ActionSkip || a == ActionSnooze
+}
+
+// Result is how a reminder ended
+type Result struct {
+	Action    Action
+	SnoozeFor time.Duration // how long the reminder was snoozed for, with ActionSnooze
+	Vendor    string        // the vendor ordered from, with ActionOrder
+}
+
+// DefaultTimeout is how long the popup stays open before closing by itself
+const DefaultTimeout = 3 * time.Minute
+
+// DefaultSnoozeOptions are the snooze durations offered when none are configured
+var DefaultSnoozeOptions = []time.Duration{10 * time.Minute, 30 * time.Minute, time.Hour}
+
+const fallbackMantra = "Stay focused and keep moving forward"
+
+// Messages are the subtitles shown below the title, one picked at random
+var Messages = []string{
+	"Time to order surprise dinner!",
+	"Save money!!!",
+	"Your team is counting on you",
+	"Make someone's day special",
+	"Spread joy with a meal",
+}
+
+// Button is a popup button and the action it triggers
+type Button struct {
+	Label   string
+	Action  Action
+	Primary bool
+}
+
+// Vendor is a place to order from
+type Vendor struct {
+	Name string
+	URL  string
+	Icon string // emoji shown before the name, optional
+}
+
+// Label is how the vendor is shown in the reminder
+func (v Vendor) Label() string {
+	if v.Icon == "" {
+		return v.Name
+	}
+	return v.Icon + " " + v.Name
+}
+
+// Model is everything a popup implementation needs to render the reminder,
+// so every platform shows the same content
+type Model struct {
+	Title        string
+	Emoji        string
+	ImagePath    string // local image shown instead of the emoji, if set
+	Message      string
+	MantraHeader string
+	Mantra       string
+	Buttons      []Button
+	Timeout      time.Duration
+	// SnoozeOptions are the durations the reminder can be snoozed for, the first
+	// one being the default. Without options the reminder can't be snoozed.
+	SnoozeOptions []time.Duration
+	OrderURL      string // the default vendor's URL
+	// Vendors are the places to order from, picked in the reminder when there
+	// are several. DefaultVendor is the index of the preselected one.
+	Vendors       []Vendor
+	DefaultVendor int
+	// Attempt is how many times a reminder shown again after timing out has been
+	// shown, out of Attempts. Both are 0 for reminders that aren't shown again.
+	Attempt  int
+	Attempts int
+	Theme    Theme
+}
+
+// New creates the default reminder for the given order URL and mantra, with a random
+// message. An empty mantra shows a fallback one.
+func New(orderURL, mantra string) Model {
+	if mantra == "" {
+		mantra = fallbackMantra
+	}
+
+	return Model{
+		Title:        "Surprise Dinner Reminder",
+		Emoji:        "🍕",
+		Message:      Messages[rand.Intn(len(Messages))],
+		MantraHeader: "Your mantra for today",
+		Mantra:       mantra,
+		Buttons: []Button{
+			{Label: "Skip Today", Action: ActionSkip},
+			{Label: "Order Now", Action: ActionOrder, Primary: true},
+		},
+		Timeout:       DefaultTimeout,
+		SnoozeOptions: DefaultSnoozeOptions,
+		OrderURL:      orderURL,
+		Theme:         DefaultTheme,
+	}
+}
+
+// OrderResult is the result of ordering from the vendor at index i, or from the
+// order URL when there are no vendors
+func (m Model) OrderResult(i int) Result {
+	if i < 0 || i >= len(m.Vendors) {
+		return Result{Action: ActionOrder}
+	}
+	return Result{Action: ActionOrder, Vendor: m.Vendors[i].Name}
+}
+
+// CanOrder reports whether the reminder offers to order. It doesn't when no vendor
+// has a usable URL.
+func (m Model) CanOrder() bool {
+	for _, b := range m.Buttons {
+		if b.Action == ActionOrder {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderURLFor returns the URL to open for an order result
+func (m Model) OrderURLFor(r Result) string {
+	for _, v := range m.Vendors {
+		if v.Name == r.Vendor {
+			return v.URL
+		}
+	}
+	return m.OrderURL
+}
+
+// Subtitle is the message shown below the title, telling how many times a reminder
+// shown again after timing out has been shown, e.g. "Save money!!! · reminder 2 of 3"
+func (m Model) Subtitle() string {
+	if m.Attempt < 2 || m.Attempts == 0 {
+		return m.Message
+	}
+	return fmt.Sprintf("%s · reminder %d of %d", m.Message, m.Attempt, m.Attempts)
+}
+
+// ShortcutHints describes the popup's keyboard shortcuts in the order of its
+// buttons: Enter presses the primary button, Escape skips and S snoozes
+func (m Model) ShortcutHints() []string {
+	var hints []string
+	for _, b := range m.Buttons {
+		switch {
+		case b.Primary:
+			hints = append(hints, "Enter: "+b.Label)
+		case b.Action == ActionSkip:
+			hints = append(hints, "Esc: "+b.Label)
+		}
+	}
+	if len(m.SnoozeOptions) > 0 {
+		hints = append(hints, "S: Snooze")
+	}
+	return hints
+}
+
+// SnoozeLabel is the button label for snoozing for d
+func SnoozeLabel(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		if d == time.Hour {
+			return "Snooze 1 hour"
+		}
+		return fmt.Sprintf("Snooze %d hours", int(d.Hours()))
+	}
+	return fmt.Sprintf("Snooze %d min", int(d.Minutes()))
+}
@@ -0,0 +1,136 @@
+package model
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	m := New("https://example.com", "Ship it")
+
+	if m.OrderURL != "https://example.com" {
+		t.Errorf("Expected order URL 'https://example.com', got '%s'", m.OrderURL)
+	}
+	if m.Title == "" || m.Emoji == "" || m.MantraHeader == "" {
+		t.Error("Expected title, emoji and mantra header to be set")
+	}
+	if !slices.Contains(Messages, m.Message) {
+		t.Errorf("Expected message from Messages, got '%s'", m.Message)
+	}
+	if m.Mantra != "Ship it" {
+		t.Errorf("Expected mantra 'Ship it', got '%s'", m.Mantra)
+	}
+	if New("https://example.com", "").Mantra != fallbackMantra {
+		t.Error("Expected the fallback mantra without one")
+	}
+	if m.Timeout != DefaultTimeout {
+		t.Errorf("Expected timeout %v, got %v", DefaultTimeout, m.Timeout)
+	}
+
+	var actions []Action
+	primary := 0
+	for _, b := range m.Buttons {
+		actions = append(actions, b.Action)
+		if b.Primary {
+			primary++
+		}
+	}
+	if !slices.Contains(actions, ActionOrder) || !slices.Contains(actions, ActionSkip) {
+		t.Errorf("Expected order and skip buttons, got %v", actions)
+	}
+	if primary != 1 {
+		t.Errorf("Expected exactly one primary button, got %d", primary)
+	}
+}
+
+func TestSnoozeLabel(t *testing.T) {
+	tests := []struct {
+		d        time.Duration
+		expected string
+	}{
+		{10 * time.Minute, "Snooze 10 min"},
+		{90 * time.Minute, "Snooze 90 min"},
+		{time.Hour, "Snooze 1 hour"},
+		{2 * time.Hour, "Snooze 2 hours"},
+	}
+
+	for _, tt := range tests {
+		if got := SnoozeLabel(tt.d); got != tt.expected {
+			t.Errorf("SnoozeLabel(%v) = %s, expected %s", tt.d, got, tt.expected)
+		}
+	}
+}
+
+func TestSubtitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempt  int
+		attempts int
+		expected string
+	}{
+		{"not shown again", 0, 0, "Save money!!!"},
+		{"first showing", 1, 3, "Save money!!!"},
+		{"shown again", 2, 3, "Save money!!! · reminder 2 of 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{Message: "Save money!!!", Attempt: tt.attempt, Attempts: tt.attempts}
+			if got := m.Subtitle(); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestOrderResult(t *testing.T) {
+	m := New("https://fallback.example.com", "")
+	m.Vendors = []Vendor{
+		{Name: "Wolt", URL: "https://wolt.com"},
+		{Name: "Foodora", URL: "https://foodora.no", Icon: "🛵"},
+	}
+
+	tests := []struct {
+		index  int
+		vendor string
+		url    string
+	}{
+		{0, "Wolt", "https://wolt.com"},
+		{1, "Foodora", "https://foodora.no"},
+		{2, "", "https://fallback.example.com"},
+		{-1, "", "https://fallback.example.com"},
+	}
+
+	for _, tt := range tests {
+		r := m.OrderResult(tt.index)
+		if r.Action != ActionOrder || r.Vendor != tt.vendor {
+			t.Errorf("OrderResult(%d) = %+v, expected an order from %q", tt.index, r, tt.vendor)
+		}
+		if got := m.OrderURLFor(r); got != tt.url {
+			t.Errorf("OrderURLFor(%+v) = %s, expected %s", r, got, tt.url)
+		}
+	}
+}
+
+func TestVendorLabel(t *testing.T) {
+	if got := (Vendor{Name: "Wolt"}).Label(); got != "Wolt" {
+		t.Errorf("Expected 'Wolt', got '%s'", got)
+	}
+	if got := (Vendor{Name: "Foodora", Icon: "🛵"}).Label(); got != "🛵 Foodora" {
+		t.Errorf("Expected '🛵 Foodora', got '%s'", got)
+	}
+}
+
+func TestShortcutHints(t *testing.T) {
+	m := New("https://example.com", "")
+	expected := []string{"Esc: Skip Today", "Enter: Order Now", "S: Snooze"}
+	if got := m.ShortcutHints(); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	m.SnoozeOptions = nil
+	if got := m.ShortcutHints(); !slices.Equal(got, expected[:2]) {
+		t.Errorf("Expected no snooze hint without snooze options, got %v", got)
+	}
+}
@@ -0,0 +1,128 @@
+package model
+
+import "image/color"
+
+// ThemeMode selects the colours the popup is drawn with
+type ThemeMode string
+
+const (
+	ThemeDark   ThemeMode = "dark"
+	ThemeLight  ThemeMode = "light"
+	ThemeSystem ThemeMode = "system" // light or dark, following the system setting
+	ThemeCustom ThemeMode = "custom"
+)
+
+// Palette is the set of colours a popup is drawn with
+type Palette struct {
+	Background color.NRGBA
+	Foreground color.NRGBA
+	Muted      color.NRGBA // secondary text, like the subtitle
+	Surface    color.NRGBA // secondary buttons and separators
+	Accent     color.NRGBA // the primary button
+}
+
+// DarkPalette is the look the popup always had
+var DarkPalette = Palette{
+	Background: color.NRGBA{0x2D, 0x2D, 0x30, 0xFF},
+	Foreground: color.NRGBA{0xFF, 0xFF, 0xFF, 0xFF},
+	Muted:      color.NRGBA{0xB4, 0xB4, 0xB4, 0xFF},
+	Surface:    color.NRGBA{0x50, 0x50, 0x50, 0xFF},
+	Accent:     color.NRGBA{0x00, 0x7A, 0xCC, 0xFF},
+}
+
+// LightPalette is the light counterpart of DarkPalette
+var LightPalette = Palette{
+	Background: color.NRGBA{0xF3, 0xF3, 0xF3, 0xFF},
+	Foreground: color.NRGBA{0x1E, 0x1E, 0x1E, 0xFF},
+	Muted:      color.NRGBA{0x5F, 0x5F, 0x5F, 0xFF},
+	Surface:    color.NRGBA{0xDA, 0xDA, 0xDA, 0xFF},
+	Accent:     color.NRGBA{0x00, 0x5F, 0xB8, 0xFF},
+}
+
+// NewPalette builds a custom palette from its background and foreground, mixing the
+// other colours from the two. The accent is the default one for light or dark.
+func NewPalette(background, foreground color.NRGBA) Palette {
+	accent := DarkPalette.Accent
+	if !isDark(background) {
+		accent = LightPalette.Accent
+	}
+	return Palette{
+		Background: background,
+		Foreground: foreground,
+		Muted:      mix(background, foreground, 0.7),
+		Surface:    mix(background, foreground, 0.2),
+		Accent:     accent,
+	}
+}
+
+// OnAccent is the colour of text on the accent, white or black for contrast
+func (p Palette) OnAccent() color.NRGBA {
+	if isDark(p.Accent) {
+		return color.NRGBA{0xFF, 0xFF, 0xFF, 0xFF}
+	}
+	return color.NRGBA{0x00, 0x00, 0x00, 0xFF}
+}
+
+// Theme is how the popup looks
+type Theme struct {
+	Mode      ThemeMode
+	Custom    Palette     // the colours of ThemeCustom
+	Accent    color.NRGBA // replaces the palette's accent unless transparent
+	FontScale float32     // text size relative to the default, 0 means unscaled
+}
+
+// DefaultTheme is used when no theme is configured
+var DefaultTheme = Theme{Mode: ThemeDark}
+
+// Resolve turns a theme following the system into a light or dark one
+func (t Theme) Resolve(systemDark bool) Theme {
+	if t.Mode == ThemeSystem {
+		t.Mode = ThemeLight
+		if systemDark {
+			t.Mode = ThemeDark
+		}
+	}
+	return t
+}
+
+// Palette returns the colours to draw with. Themes following the system are
+// expected to be resolved first, they are dark otherwise.
+func (t Theme) Palette() Palette {
+	p := DarkPalette
+	switch t.Mode {
+	case ThemeLight:
+		p = LightPalette
+	case ThemeCustom:
+		p = t.Custom
+	}
+	if t.Accent.A != 0 {
+		p.Accent = t.Accent
+	}
+	return p
+}
+
+// Dark reports whether the theme has a dark background
+func (t Theme) Dark() bool {
+	return isDark(t.Palette().Background)
+}
+
+// Scale returns the factor text sizes are multiplied with
+func (t Theme) Scale() float32 {
+	if t.FontScale <= 0 {
+		return 1
+	}
+	return t.FontScale
+}
+
+// isDark reports whether c is closer to black than to white, by its relative luminance
+func isDark(c color.NRGBA) bool {
+	return 0.2126*float64(c.R)+0.7152*float64(c.G)+0.0722*float64(c.B) < 128
+}
+
+// mix blends from a towards b by weight
+func mix(a, b color.NRGBA, weight float64) color.NRGBA {
+	blend := func(x, y uint8) uint8 {
+		return uint8(float64(x) + (float64(y)-float64(x))*weight + 0.5)
+	}
+	return color.NRGBA{blend(a.R, b.R), blend(a.G, b.G), blend(a.B, b.B), 0xFF}
+}
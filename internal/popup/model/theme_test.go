@@ -0,0 +1,68 @@
+package model
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestThemePalette(t *testing.T) {
+	red := color.NRGBA{0xFF, 0x00, 0x00, 0xFF}
+	custom := NewPalette(color.NRGBA{0xFF, 0xF8, 0xE7, 0xFF}, color.NRGBA{0x33, 0x22, 0x11, 0xFF})
+
+	tests := []struct {
+		name       string
+		theme      Theme
+		systemDark bool
+		expected   Palette
+		dark       bool
+	}{
+		{"dark", Theme{Mode: ThemeDark}, false, DarkPalette, true},
+		{"light", Theme{Mode: ThemeLight}, true, LightPalette, false},
+		{"system dark", Theme{Mode: ThemeSystem}, true, DarkPalette, true},
+		{"system light", Theme{Mode: ThemeSystem}, false, LightPalette, false},
+		{"custom", Theme{Mode: ThemeCustom, Custom: custom}, true, custom, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved := tt.theme.Resolve(tt.systemDark)
+			if got := resolved.Palette(); got != tt.expected {
+				t.Errorf("Expected palette %+v, got %+v", tt.expected, got)
+			}
+			if resolved.Dark() != tt.dark {
+				t.Errorf("Expected dark %v, got %v", tt.dark, resolved.Dark())
+			}
+
+			resolved.Accent = red
+			if got := resolved.Palette().Accent; got != red {
+				t.Errorf("Expected the accent to be replaced, got %+v", got)
+			}
+		})
+	}
+}
+
+func TestNewPalette(t *testing.T) {
+	p := NewPalette(color.NRGBA{0x00, 0x00, 0x00, 0xFF}, color.NRGBA{0xFF, 0xFF, 0xFF, 0xFF})
+
+	if p.Muted != (color.NRGBA{0xB3, 0xB3, 0xB3, 0xFF}) {
+		t.Errorf("Expected muted text between foreground and background, got %+v", p.Muted)
+	}
+	if p.Surface != (color.NRGBA{0x33, 0x33, 0x33, 0xFF}) {
+		t.Errorf("Expected the surface close to the background, got %+v", p.Surface)
+	}
+	if p.Accent != DarkPalette.Accent {
+		t.Errorf("Expected the dark accent on a dark background, got %+v", p.Accent)
+	}
+	if p.OnAccent() != (color.NRGBA{0xFF, 0xFF, 0xFF, 0xFF}) {
+		t.Errorf("Expected white text on the accent, got %+v", p.OnAccent())
+	}
+}
+
+func TestThemeScale(t *testing.T) {
+	if s := DefaultTheme.Scale(); s != 1 {
+		t.Errorf("Expected the default theme to be unscaled, got %v", s)
+	}
+	if s := (Theme{FontScale: 1.5}).Scale(); s != 1.5 {
+		t.Errorf("Expected scale 1.5, got %v", s)
+	}
+}
@@ -0,0 +1,105 @@
+package notification
+
+import (
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	busName    = "org.freedesktop.Notifications"
+	objectPath = dbus.ObjectPath("/org/freedesktop/Notifications")
+)
+
+type dbusConn struct {
+	conn    *dbus.Conn
+	obj     dbus.BusObject
+	signals chan Signal
+}
+
+// Dial connects to the notification server on the session bus
+func Dial() (Conn, error) {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the session bus: %w", err)
+	}
+	c, err := NewDBusConn(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// NewDBusConn uses an open bus connection, such as a private session bus, to talk to
+// the notification server. Closing the returned Conn closes the bus connection.
+func NewDBusConn(conn *dbus.Conn) (Conn, error) {
+	if err := conn.AddMatchSignal(
+		dbus.WithMatchObjectPath(objectPath),
+		dbus.WithMatchInterface(busName),
+	); err != nil {
+		return nil, fmt.Errorf("failed to subscribe to notification signals: %w", err)
+	}
+
+	c := &dbusConn{
+		conn:    conn,
+		obj:     conn.Object(busName, objectPath),
+		signals: make(chan Signal, 16),
+	}
+	in := make(chan *dbus.Signal, 16)
+	conn.Signal(in)
+	go c.forward(in)
+	return c, nil
+}
+
+// forward translates bus signals until the connection is closed
+func (c *dbusConn) forward(in <-chan *dbus.Signal) {
+	defer close(c.signals)
+	for s := range in {
+		if len(s.Body) != 2 {
+			continue
+		}
+		id, _ := s.Body[0].(uint32)
+		switch s.Name {
+		case busName + ".ActionInvoked":
+			key, _ := s.Body[1].(string)
+			c.signals <- Signal{ID: id, ActionKey: key}
+		case busName + ".NotificationClosed":
+			reason, _ := s.Body[1].(uint32)
+			c.signals <- Signal{ID: id, Closed: true, Reason: reason}
+		}
+	}
+}
+
+func (c *dbusConn) Capabilities() ([]string, error) {
+	var caps []string
+	err := c.obj.Call(busName+".GetCapabilities", 0).Store(&caps)
+	return caps, err
+}
+
+func (c *dbusConn) Notify(n Notification) (uint32, error) {
+	var id uint32
+	err := c.obj.Call(busName+".Notify", 0,
+		appName,
+		uint32(0), // replaces id
+		"",        // icon
+		n.Summary,
+		n.Body,
+		n.Actions,
+		map[string]dbus.Variant{},
+		int32(n.Timeout.Milliseconds()),
+	).Store(&id)
+	return id, err
+}
+
+func (c *dbusConn) CloseNotification(id uint32) error {
+	return c.obj.Call(busName+".CloseNotification", 0, id).Err
+}
+
+func (c *dbusConn) Signals() <-chan Signal {
+	return c.signals
+}
+
+func (c *dbusConn) Close() error {
+	return c.conn.Close()
+}
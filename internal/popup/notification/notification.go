@@ -0,0 +1,150 @@
+package notification
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sultengutt/internal/popup/model"
+	"time"
+)
+
+const appName = "Sultengutt"
+
+var closed = model.Result{Action: model.ActionClose}
+
+// Reasons a notification server gives for closing a notification
+const (
+	ClosedExpired   uint32 = 1
+	ClosedDismissed uint32 = 2
+	ClosedByCall    uint32 = 3
+)
+
+// Notification is a desktop notification with action buttons
+type Notification struct {
+	Summary string
+	Body    string
+	Actions []string // pairs of action key and button label
+	Timeout time.Duration
+}
+
+// Signal is an ActionInvoked or NotificationClosed signal from the notification server
+type Signal struct {
+	ID        uint32
+	ActionKey string // set when an action was invoked
+	Closed    bool
+	Reason    uint32
+}
+
+// Conn is a connection to an org.freedesktop.Notifications server
+type Conn interface {
+	Capabilities() ([]string, error)
+	Notify(n Notification) (uint32, error)
+	CloseNotification(id uint32) error
+	Signals() <-chan Signal
+	Close() error
+}
+
+// Notifier shows the reminder as a desktop notification
+type Notifier struct {
+	conn    Conn
+	openURL func(string) error
+	after   func(time.Duration) <-chan time.Time
+}
+
+// New creates a notifier on conn. openURL opens the order page when the user picks Order.
+func New(conn Conn, openURL func(string) error) *Notifier {
+	return &Notifier{conn: conn, openURL: openURL, after: time.After}
+}
+
+// Show sends the reminder and blocks until the user picks an action, dismisses it
+// or it times out
+func (n *Notifier) Show(m model.Model) (model.Result, error) {
+	caps, err := n.conn.Capabilities()
+	if err != nil {
+		return closed, fmt.Errorf("failed to get notification server capabilities: %w", err)
+	}
+	if !slices.Contains(caps, "actions") {
+		return closed, fmt.Errorf("notification server does not support action buttons")
+	}
+
+	id, err := n.conn.Notify(newNotification(m, slices.Contains(caps, "body-markup")))
+	if err != nil {
+		return closed, fmt.Errorf("failed to send notification: %w", err)
+	}
+
+	var timeout <-chan time.Time
+	if m.Timeout > 0 {
+		// not every server honours the expiry, so close it ourselves as well
+		timeout = n.after(m.Timeout)
+	}
+
+	for {
+		select {
+		case <-timeout:
+			n.conn.CloseNotification(id)
+			return model.Result{Action: model.ActionTimeout}, nil
+		case s, ok := <-n.conn.Signals():
+			if !ok {
+				return closed, fmt.Errorf("lost connection to the notification server")
+			}
+			if s.ID != id {
+				continue
+			}
+			if s.Closed {
+				if s.Reason == ClosedExpired {
+					return model.Result{Action: model.ActionTimeout}, nil
+				}
+				return closed, nil
+			}
+
+			result := model.Result{Action: model.Action(s.ActionKey)}
+			switch result.Action {
+			case model.ActionOrder:
+				// notifications can't show a picker, orders go to the default vendor
+				result = m.OrderResult(m.DefaultVendor)
+				n.conn.CloseNotification(id)
+				url := m.OrderURLFor(result)
+				if err := n.openURL(url); err != nil {
+					return result, fmt.Errorf("failed to open %s: %w", url, err)
+				}
+				return result, nil
+			case model.ActionSnooze:
+				result.SnoozeFor = m.SnoozeOptions[0]
+				n.conn.CloseNotification(id)
+				return result, nil
+			case model.ActionSkip:
+				n.conn.CloseNotification(id)
+				return result, nil
+			}
+		}
+	}
+}
+
+// newNotification builds the notification for a model, with the popup's buttons
+// as actions plus the default snooze, as servers only show a few buttons. The mantra
+// is in italics if the server renders markup, servers that don't would show the tags.
+func newNotification(m model.Model, markup bool) Notification {
+	var actions []string
+	for _, b := range m.Buttons {
+		actions = append(actions, string(b.Action), b.Label)
+	}
+	if len(m.SnoozeOptions) > 0 {
+		actions = append(actions, string(model.ActionSnooze), model.SnoozeLabel(m.SnoozeOptions[0]))
+	}
+
+	body := m.Subtitle() + "\n\n\"" + m.Mantra + "\""
+	if markup {
+		body = escapeMarkup(m.Subtitle()) + "\n\n<i>\"" + escapeMarkup(m.Mantra) + "\"</i>"
+	}
+	return Notification{
+		Summary: m.Emoji + " " + m.Title,
+		Body:    body,
+		Actions: actions,
+		Timeout: m.Timeout,
+	}
+}
+
+// escapeMarkup escapes text for servers that render the body markup
+func escapeMarkup(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,252 @@
+package notification
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sultengutt/internal/popup/model"
+	"testing"
+	"time"
+)
+
+// fakeConn is a notification server that answers each notification with the next
+// queued signal
+type fakeConn struct {
+	caps    []string
+	replies []Signal
+	sent    []Notification
+	closed  []uint32
+	signals chan Signal
+}
+
+func newFakeConn(replies ...Signal) *fakeConn {
+	return &fakeConn{
+		caps:    []string{"actions", "body"},
+		replies: replies,
+		signals: make(chan Signal, 16),
+	}
+}
+
+func (f *fakeConn) Capabilities() ([]string, error) { return f.caps, nil }
+
+func (f *fakeConn) Notify(n Notification) (uint32, error) {
+	f.sent = append(f.sent, n)
+	id := uint32(len(f.sent))
+	if len(f.replies) > 0 {
+		reply := f.replies[0]
+		f.replies = f.replies[1:]
+		// a signal for another notification arrives first and must be ignored
+		f.signals <- Signal{ID: id + 100, ActionKey: string(model.ActionOrder)}
+		reply.ID = id
+		f.signals <- reply
+	}
+	return id, nil
+}
+
+func (f *fakeConn) CloseNotification(id uint32) error {
+	f.closed = append(f.closed, id)
+	return nil
+}
+
+func (f *fakeConn) Signals() <-chan Signal { return f.signals }
+
+func (f *fakeConn) Close() error { return nil }
+
+func testModel() model.Model {
+	return model.Model{
+		Title:   "Test Title",
+		Emoji:   "🍕",
+		Message: "Fish & chips <today>",
+		Mantra:  "Ship it",
+		Buttons: []model.Button{
+			{Label: "Skip", Action: model.ActionSkip},
+			{Label: "Order", Action: model.ActionOrder, Primary: true},
+		},
+		Timeout:       time.Minute,
+		SnoozeOptions: []time.Duration{10 * time.Minute, time.Hour},
+		OrderURL:      "https://example.com",
+	}
+}
+
+// never is a timer that doesn't fire
+func never(time.Duration) <-chan time.Time { return nil }
+
+func now(time.Duration) <-chan time.Time {
+	c := make(chan time.Time, 1)
+	c <- time.Now()
+	return c
+}
+
+func TestShow(t *testing.T) {
+	tests := []struct {
+		name     string
+		reply    Signal
+		expected model.Action
+		opened   bool
+	}{
+		{"order", Signal{ActionKey: "order"}, model.ActionOrder, true},
+		{"skip", Signal{ActionKey: "skip"}, model.ActionSkip, false},
+		{"expired", Signal{Closed: true, Reason: ClosedExpired}, model.ActionTimeout, false},
+		{"dismissed", Signal{Closed: true, Reason: ClosedDismissed}, model.ActionClose, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := newFakeConn(tt.reply)
+			var opened []string
+			n := New(conn, func(url string) error {
+				opened = append(opened, url)
+				return nil
+			})
+			n.after = never
+
+			result, err := n.Show(testModel())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result.Action != tt.expected {
+				t.Errorf("Expected action %s, got %s", tt.expected, result.Action)
+			}
+			if tt.opened != (len(opened) == 1 && opened[0] == "https://example.com") {
+				t.Errorf("Expected order URL opened: %v, got %v", tt.opened, opened)
+			}
+		})
+	}
+}
+
+func TestShowOrderDefaultVendor(t *testing.T) {
+	conn := newFakeConn(Signal{ActionKey: "order"})
+	var opened []string
+	n := New(conn, func(url string) error {
+		opened = append(opened, url)
+		return nil
+	})
+	n.after = never
+
+	m := testModel()
+	m.Vendors = []model.Vendor{
+		{Name: "Wolt", URL: "https://wolt.com"},
+		{Name: "Foodora", URL: "https://foodora.no"},
+	}
+	m.DefaultVendor = 1
+
+	result, err := n.Show(m)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := model.Result{Action: model.ActionOrder, Vendor: "Foodora"}
+	if result != expected {
+		t.Errorf("Expected %+v, got %+v", expected, result)
+	}
+	if !slices.Equal(opened, []string{"https://foodora.no"}) {
+		t.Errorf("Expected the default vendor to be opened, got %v", opened)
+	}
+}
+
+func TestShowTimeout(t *testing.T) {
+	conn := newFakeConn()
+	n := New(conn, nil)
+	n.after = now
+
+	result, err := n.Show(testModel())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Action != model.ActionTimeout {
+		t.Errorf("Expected timeout, got %s", result.Action)
+	}
+	if !slices.Equal(conn.closed, []uint32{1}) {
+		t.Errorf("Expected the notification to be closed, got %v", conn.closed)
+	}
+}
+
+func TestShowSnooze(t *testing.T) {
+	conn := newFakeConn(Signal{ActionKey: "snooze"})
+	n := New(conn, nil)
+	n.after = never
+
+	result, err := n.Show(testModel())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := model.Result{Action: model.ActionSnooze, SnoozeFor: 10 * time.Minute}
+	if result != expected {
+		t.Errorf("Expected %+v, got %+v", expected, result)
+	}
+	if !slices.Equal(conn.closed, []uint32{1}) {
+		t.Errorf("Expected the notification to be closed, got %v", conn.closed)
+	}
+}
+
+func TestShowErrors(t *testing.T) {
+	t.Run("no actions", func(t *testing.T) {
+		conn := newFakeConn()
+		conn.caps = []string{"body"}
+		if _, err := New(conn, nil).Show(testModel()); err == nil {
+			t.Error("Expected an error for a server without action support")
+		}
+	})
+
+	t.Run("open fails", func(t *testing.T) {
+		conn := newFakeConn(Signal{ActionKey: "order"})
+		n := New(conn, func(string) error { return errors.New("no browser") })
+		n.after = never
+
+		result, err := n.Show(testModel())
+		if err == nil || !strings.Contains(err.Error(), "no browser") {
+			t.Errorf("Expected the open error, got %v", err)
+		}
+		if result.Action != model.ActionOrder {
+			t.Errorf("Expected order action, got %s", result.Action)
+		}
+	})
+
+	t.Run("connection lost", func(t *testing.T) {
+		conn := newFakeConn()
+		close(conn.signals)
+		n := New(conn, nil)
+		n.after = never
+
+		if _, err := n.Show(testModel()); err == nil {
+			t.Error("Expected an error when the connection is lost")
+		}
+	})
+}
+
+func TestShowBodyMarkup(t *testing.T) {
+	for _, markup := range []bool{false, true} {
+		conn := newFakeConn(Signal{ActionKey: "skip"})
+		if markup {
+			conn.caps = append(conn.caps, "body-markup")
+		}
+		n := New(conn, nil)
+		n.after = never
+		if _, err := n.Show(testModel()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got := strings.Contains(conn.sent[0].Body, "<i>"); got != markup {
+			t.Errorf("Expected markup %v in the body, got '%s'", markup, conn.sent[0].Body)
+		}
+	}
+}
+
+func TestNewNotification(t *testing.T) {
+	n := newNotification(testModel(), true)
+
+	if n.Summary != "🍕 Test Title" {
+		t.Errorf("Unexpected summary '%s'", n.Summary)
+	}
+	if !strings.Contains(n.Body, "Fish &amp; chips &lt;today&gt;") || !strings.Contains(n.Body, "<i>\"Ship it\"</i>") {
+		t.Errorf("Expected escaped message and mantra in italics in body, got '%s'", n.Body)
+	}
+	if plain := newNotification(testModel(), false); plain.Body != "Fish & chips <today>\n\n\"Ship it\"" {
+		t.Errorf("Expected a plain text body without markup support, got '%s'", plain.Body)
+	}
+	expected := []string{"skip", "Skip", "order", "Order", "snooze", "Snooze 10 min"}
+	if !slices.Equal(n.Actions, expected) {
+		t.Errorf("Expected actions %v, got %v", expected, n.Actions)
+	}
+	if n.Timeout != time.Minute {
+		t.Errorf("Expected timeout 1m, got %v", n.Timeout)
+	}
+}
@@ -0,0 +1,18 @@
+package popup
+
+import (
+	"sultengutt/internal/popup/model"
+	"sultengutt/internal/popup/notification"
+)
+
+// ShowNotification shows the reminder as a desktop notification over D-Bus and
+// returns what the user chose. It fails when there is no notification server that
+// supports action buttons.
+func ShowNotification(m model.Model) (model.Result, error) {
+	conn, err := notification.Dial()
+	if err != nil {
+		return model.Result{Action: model.ActionClose}, err
+	}
+	defer conn.Close()
+	return notification.New(conn, openURL).Show(m)
+}
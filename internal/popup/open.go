@@ -0,0 +1,15 @@
+package popup
+
+import "sultengutt/internal/opener"
+
+// urlOpener opens the order page from the reminders
+var urlOpener = opener.Default()
+
+// SetOpener sets how the reminders open the order page, the system browser by default
+func SetOpener(o *opener.Opener) {
+	urlOpener = o
+}
+
+func openURL(url string) error {
+	return urlOpener.Open(url)
+}
@@ -1,7 +1,18 @@
 package popup
 
-// ShowPopup is the platform-specific popup implementation
-// The actual implementation is in popup_darwin.go and popup_windows.go
-func ShowPopup(siteLink string) {
-	showPopup(siteLink)
+import (
+	"image"
+	"sultengutt/internal/popup/model"
+)
+
+// ShowPopup shows the reminder popup and returns what the user chose.
+// The actual implementation is in popup_fyne.go (macOS and Linux) and popup_windows.go
+func ShowPopup(m model.Model) (model.Result, error) {
+	return showPopup(m)
+}
+
+// Screenshot renders the reminder popup to an image without showing it, so it
+// works without a display
+func Screenshot(m model.Model) (image.Image, error) {
+	return screenshot(m)
 }
@@ -0,0 +1,17 @@
+//go:build darwin || linux
+
+package popup
+
+import (
+	"image"
+	"sultengutt/internal/popup/gui"
+	"sultengutt/internal/popup/model"
+)
+
+func showPopup(m model.Model) (model.Result, error) {
+	return gui.Run(m, openURL), nil
+}
+
+func screenshot(m model.Model) (image.Image, error) {
+	return gui.Screenshot(m), nil
+}
@@ -4,9 +4,28 @@
 package popup
 
 import (
+	"errors"
+	"fmt"
+	"image"
+	"sultengutt/internal/popup/model"
 	winpop "sultengutt/internal/popup/windows"
 )
 
-func showPopup(siteLink string) {
-	winpop.RunWindowsPopup()
+func showPopup(m model.Model) (model.Result, error) {
+	result, err := winpop.RunWindowsPopup(m)
+	if err != nil {
+		return result, err
+	}
+	// the script only reports the choice, so the URL never goes through PowerShell
+	if result.Action == model.ActionOrder {
+		url := m.OrderURLFor(result)
+		if err := openURL(url); err != nil {
+			return result, fmt.Errorf("failed to open %s: %w", url, err)
+		}
+	}
+	return result, nil
+}
+
+func screenshot(model.Model) (image.Image, error) {
+	return nil, errors.New("screenshots are rendered with the macOS and Linux popup, which isn't available on Windows")
 }
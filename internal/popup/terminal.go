@@ -0,0 +1,27 @@
+package popup
+
+import (
+	"os"
+	"runtime"
+	"sultengutt/internal/popup/model"
+	"sultengutt/internal/popup/tui"
+)
+
+// HasDisplay reports whether a graphical popup can be shown. Linux needs an X11 or
+// Wayland display; on the other platforms SSH sessions have no desktop to show it on.
+func HasDisplay() bool {
+	if runtime.GOOS == "linux" && os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+		return false
+	}
+	return os.Getenv("SSH_CONNECTION") == "" && os.Getenv("SSH_TTY") == ""
+}
+
+// ShowTerminalReminder shows the reminder in the terminal and returns what the user chose.
+// The order page is only opened in a browser when there is a display to open it on.
+func ShowTerminalReminder(m model.Model) (model.Result, error) {
+	var opener func(string) error
+	if HasDisplay() {
+		opener = openURL
+	}
+	return tui.Run(m, opener)
+}
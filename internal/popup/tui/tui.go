@@ -0,0 +1,240 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"sultengutt/internal/popup/model"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	boxStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#FF6B6B")).
+			Padding(1, 3).
+			Align(lipgloss.Center)
+
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#FF6B6B"))
+
+	messageStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#C8C8C8"))
+
+	mantraHeaderStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#969696"))
+
+	mantraStyle = lipgloss.NewStyle().
+			Italic(true)
+
+	keyStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#3498DB"))
+
+	hintStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#7F8C8D"))
+
+	errorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#E74C3C"))
+)
+
+type tickMsg time.Time
+
+// openedMsg reports that opening the order URL finished, with err if it failed
+type openedMsg struct {
+	url string
+	err error
+}
+
+// Reminder is the bubbletea model for the terminal reminder
+type Reminder struct {
+	model   model.Model
+	openURL func(string) error
+
+	remaining time.Duration
+	vendor    int           // index of the selected vendor
+	opening   bool          // the order URL is being opened
+	snoozeFor time.Duration // for snoozes: how long
+	action    model.Action
+	message   string // feedback shown after choosing, e.g. the order URL
+}
+
+// NewReminder creates the terminal reminder. openURL may be nil when there is no
+// browser to open, e.g. over SSH; the order URL is printed either way.
+func NewReminder(m model.Model, openURL func(string) error) *Reminder {
+	return &Reminder{
+		model:     m,
+		openURL:   openURL,
+		remaining: m.Timeout,
+		vendor:    m.DefaultVendor,
+	}
+}
+
+// Run shows the reminder in the terminal and blocks until the user has made a
+// choice or the reminder timed out. Snoozes are scheduled by the caller, like the
+// popup's.
+func Run(m model.Model, openURL func(string) error) (model.Result, error) {
+	result, err := tea.NewProgram(NewReminder(m, openURL)).Run()
+	if err != nil {
+		return model.Result{Action: model.ActionClose}, fmt.Errorf("failed to run terminal reminder: %w", err)
+	}
+	r := result.(*Reminder)
+	if r.message != "" {
+		fmt.Println(r.message)
+	}
+	return r.Result(), nil
+}
+
+// Result returns how the reminder ended, with the vendor chosen for orders and the
+// duration of snoozes
+func (r *Reminder) Result() model.Result {
+	switch r.Action() {
+	case model.ActionOrder:
+		return r.model.OrderResult(r.vendor)
+	case model.ActionSnooze:
+		return model.Result{Action: model.ActionSnooze, SnoozeFor: r.snoozeFor}
+	}
+	return model.Result{Action: r.Action()}
+}
+
+// Action returns how the reminder ended
+func (r *Reminder) Action() model.Action {
+	if r.action == "" {
+		return model.ActionClose
+	}
+	return r.action
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return tickMsg(t) })
+}
+
+func (r *Reminder) Init() tea.Cmd {
+	return tick()
+}
+
+func (r *Reminder) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return r.handleKey(msg.String())
+	case openedMsg:
+		if msg.err != nil {
+			r.message = errorStyle.Render("Failed to open browser: "+msg.err.Error()) + "\n"
+		}
+		r.message += "Order here: " + msg.url
+		return r.finish(model.ActionOrder)
+	case tickMsg:
+		if r.opening {
+			// the user has ordered, the reminder no longer times out
+			return r, nil
+		}
+		r.remaining -= time.Second
+		if r.remaining > 0 {
+			return r, tick()
+		}
+		if r.model.Timeout > 0 {
+			return r.finish(model.ActionTimeout)
+		}
+		return r, nil
+	}
+	return r, nil
+}
+
+func (r *Reminder) handleKey(key string) (tea.Model, tea.Cmd) {
+	if r.opening && key != "ctrl+c" {
+		// the user has ordered already
+		return r, nil
+	}
+	if key == "ctrl+c" || key == "q" {
+		return r.finish(model.ActionClose)
+	}
+
+	switch key {
+	case "enter", "o":
+		if !r.model.CanOrder() {
+			return r, nil
+		}
+		url := r.model.OrderURLFor(r.model.OrderResult(r.vendor))
+		if r.openURL == nil {
+			r.message = "Order here: " + url
+			return r.finish(model.ActionOrder)
+		}
+		// starting the browser can take a while, so it's not done in Update
+		r.opening = true
+		openURL := r.openURL
+		return r, func() tea.Msg { return openedMsg{url: url, err: openURL(url)} }
+	case "tab", "right":
+		if n := len(r.model.Vendors); n > 1 {
+			r.vendor = (r.vendor + 1) % n
+		}
+	case "shift+tab", "left":
+		if n := len(r.model.Vendors); n > 1 {
+			r.vendor = (r.vendor + n - 1) % n
+		}
+	case "esc", "x":
+		return r.finish(model.ActionSkip)
+	case "s":
+		if len(r.model.SnoozeOptions) > 0 {
+			r.snoozeFor = r.model.SnoozeOptions[0]
+			r.message = "Snoozed, reminding you again in " + formatCountdown(r.snoozeFor)
+			return r.finish(model.ActionSnooze)
+		}
+	}
+	return r, nil
+}
+
+func (r *Reminder) finish(action model.Action) (tea.Model, tea.Cmd) {
+	r.action = action
+	return r, tea.Quit
+}
+
+func (r *Reminder) View() string {
+	if r.action != "" {
+		return ""
+	}
+
+	var hints []string
+	for _, b := range r.model.Buttons {
+		switch b.Action {
+		case model.ActionOrder:
+			hints = append(hints, keyStyle.Render("enter")+hintStyle.Render(" "+b.Label))
+		case model.ActionSkip:
+			hints = append(hints, keyStyle.Render("esc")+hintStyle.Render(" "+b.Label))
+		}
+	}
+	if len(r.model.SnoozeOptions) > 0 {
+		hints = append(hints, keyStyle.Render("s")+hintStyle.Render(" "+model.SnoozeLabel(r.model.SnoozeOptions[0])))
+	}
+
+	var b strings.Builder
+	b.WriteString(r.model.Emoji + "\n\n")
+	b.WriteString(titleStyle.Render(r.model.Title) + "\n")
+	b.WriteString(messageStyle.Render(r.model.Subtitle()) + "\n\n")
+	b.WriteString(mantraHeaderStyle.Render(r.model.MantraHeader) + "\n")
+	b.WriteString(mantraStyle.Render("\""+r.model.Mantra+"\"") + "\n\n")
+	if len(r.model.Vendors) > 1 {
+		b.WriteString(messageStyle.Render("Order from: ") + keyStyle.Render("‹ "+r.model.Vendors[r.vendor].Label()+" ›") +
+			hintStyle.Render("  tab to change") + "\n\n")
+	}
+	if r.opening {
+		b.WriteString(hintStyle.Render("Opening the order page…"))
+	} else {
+		b.WriteString(strings.Join(hints, "   "))
+	}
+	if r.model.Timeout > 0 && !r.opening {
+		b.WriteString("\n\n" + hintStyle.Render("Closes in "+formatCountdown(r.remaining)))
+	}
+	return boxStyle.Render(b.String()) + "\n"
+}
+
+// formatCountdown formats a duration as m:ss
+func formatCountdown(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
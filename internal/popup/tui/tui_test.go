@@ -0,0 +1,270 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"sultengutt/internal/popup/model"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func testModel() model.Model {
+	return model.Model{
+		Title:        "Test Title",
+		Emoji:        "🍕",
+		Message:      "Test message",
+		MantraHeader: "Mantra header",
+		Mantra:       "Ship it",
+		Buttons: []model.Button{
+			{Label: "Skip", Action: model.ActionSkip},
+			{Label: "Order", Action: model.ActionOrder, Primary: true},
+		},
+		Timeout:       3 * time.Second,
+		SnoozeOptions: []time.Duration{2 * time.Second},
+		OrderURL:      "https://example.com",
+	}
+}
+
+func key(k string) tea.KeyMsg {
+	switch k {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "ctrl+c":
+		return tea.KeyMsg{Type: tea.KeyCtrlC}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+func TestKeys(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected model.Action
+	}{
+		{"enter", model.ActionOrder},
+		{"o", model.ActionOrder},
+		{"esc", model.ActionSkip},
+		{"x", model.ActionSkip},
+		{"q", model.ActionClose},
+		{"ctrl+c", model.ActionClose},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			r := NewReminder(testModel(), nil)
+			_, cmd := r.Update(key(tt.key))
+
+			if r.Action() != tt.expected {
+				t.Errorf("Expected action %s, got %s", tt.expected, r.Action())
+			}
+			if cmd == nil {
+				t.Fatal("Expected the reminder to quit")
+			}
+			if _, ok := cmd().(tea.QuitMsg); !ok {
+				t.Error("Expected a quit command")
+			}
+		})
+	}
+}
+
+func TestOrderOpensURL(t *testing.T) {
+	var opened []string
+	r := NewReminder(testModel(), func(url string) error {
+		opened = append(opened, url)
+		return nil
+	})
+	_, cmd := r.Update(key("enter"))
+	if len(opened) != 0 || r.action != "" || cmd == nil {
+		t.Fatal("Expected the URL to be opened by a command, outside of Update")
+	}
+	// keys pressed and the timeout running out while the browser starts don't change the choice
+	r.Update(key("esc"))
+	r.remaining = time.Second
+	r.Update(tickMsg(time.Now()))
+	r.Update(cmd())
+
+	if r.Action() != model.ActionOrder {
+		t.Errorf("Expected order action, got %s", r.Action())
+	}
+	if len(opened) != 1 || opened[0] != "https://example.com" {
+		t.Errorf("Expected the order URL to be opened once, got %v", opened)
+	}
+	if !strings.Contains(r.message, "https://example.com") {
+		t.Errorf("Expected the order URL to be printed, got '%s'", r.message)
+	}
+}
+
+func TestOrderOpenError(t *testing.T) {
+	r := NewReminder(testModel(), func(string) error { return errors.New("no browser") })
+	_, cmd := r.Update(key("enter"))
+	r.Update(cmd())
+
+	if r.Action() != model.ActionOrder {
+		t.Errorf("Expected order action, got %s", r.Action())
+	}
+	if !strings.Contains(r.message, "no browser") || !strings.Contains(r.message, "https://example.com") {
+		t.Errorf("Expected the error and the URL to be printed, got '%s'", r.message)
+	}
+}
+
+func TestOrderWithoutOrderButton(t *testing.T) {
+	m := testModel()
+	m.Buttons = m.Buttons[:1]
+	var opened []string
+	r := NewReminder(m, func(url string) error {
+		opened = append(opened, url)
+		return nil
+	})
+	_, cmd := r.Update(key("enter"))
+
+	if cmd != nil || r.action != "" || len(opened) != 0 {
+		t.Errorf("Expected enter to do nothing without an order button, got %s and %v", r.action, opened)
+	}
+}
+
+func TestVendorPicker(t *testing.T) {
+	m := testModel()
+	m.Vendors = []model.Vendor{
+		{Name: "Wolt", URL: "https://wolt.com"},
+		{Name: "Foodora", URL: "https://foodora.no", Icon: "🛵"},
+		{Name: "Pizzabakeren", URL: "https://pizzabakeren.no"},
+	}
+	m.DefaultVendor = 1
+
+	tests := []struct {
+		name     string
+		keys     []tea.KeyMsg
+		expected string
+	}{
+		{"default", nil, "Foodora"},
+		{"next", []tea.KeyMsg{{Type: tea.KeyTab}}, "Pizzabakeren"},
+		{"wraps around", []tea.KeyMsg{{Type: tea.KeyTab}, {Type: tea.KeyTab}}, "Wolt"},
+		{"previous", []tea.KeyMsg{{Type: tea.KeyShiftTab}}, "Wolt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opened []string
+			r := NewReminder(m, func(url string) error {
+				opened = append(opened, url)
+				return nil
+			})
+			for _, k := range tt.keys {
+				r.Update(k)
+			}
+			if !strings.Contains(r.View(), tt.expected) {
+				t.Errorf("Expected the view to show %s", tt.expected)
+			}
+			_, cmd := r.Update(key("enter"))
+			r.Update(cmd())
+
+			result := r.Result()
+			if result.Action != model.ActionOrder || result.Vendor != tt.expected {
+				t.Errorf("Expected an order from %s, got %+v", tt.expected, result)
+			}
+			if want := m.OrderURLFor(result); len(opened) != 1 || opened[0] != want {
+				t.Errorf("Expected %s to be opened, got %v", want, opened)
+			}
+		})
+	}
+}
+
+func TestSingleVendorHidesPicker(t *testing.T) {
+	r := NewReminder(testModel(), nil)
+	if strings.Contains(r.View(), "Order from") {
+		t.Error("Expected no vendor picker without vendors")
+	}
+	r.Update(tea.KeyMsg{Type: tea.KeyTab})
+	r.Update(key("enter"))
+	if result := r.Result(); result.Vendor != "" {
+		t.Errorf("Expected no vendor, got %s", result.Vendor)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	r := NewReminder(testModel(), nil)
+	for i := 0; i < 2; i++ {
+		r.Update(tickMsg(time.Now()))
+		if r.action != "" {
+			t.Fatalf("Expected the reminder to stay open after %d ticks, got %s", i+1, r.action)
+		}
+	}
+	if !strings.Contains(r.View(), "Closes in 0:01") {
+		t.Errorf("Expected the countdown in the view, got:\n%s", r.View())
+	}
+
+	r.Update(tickMsg(time.Now()))
+	if r.Action() != model.ActionTimeout {
+		t.Errorf("Expected timeout action, got %s", r.Action())
+	}
+}
+
+func TestSnooze(t *testing.T) {
+	r := NewReminder(testModel(), nil)
+	_, cmd := r.Update(key("s"))
+
+	if cmd == nil {
+		t.Fatal("Expected the reminder to quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("Expected a quit command")
+	}
+	result := r.Result()
+	if result.Action != model.ActionSnooze || result.SnoozeFor != 2*time.Second {
+		t.Errorf("Expected a snooze for 2s, got %+v", result)
+	}
+	if !strings.Contains(r.message, "0:02") {
+		t.Errorf("Expected the snooze to be printed, got '%s'", r.message)
+	}
+}
+
+func TestSnoozeWithoutOptions(t *testing.T) {
+	m := testModel()
+	m.SnoozeOptions = nil
+	r := NewReminder(m, nil)
+	r.Update(key("s"))
+
+	if r.action != "" {
+		t.Errorf("Expected no snooze without snooze options, got %s", r.action)
+	}
+}
+
+func TestViewRendersModel(t *testing.T) {
+	view := NewReminder(testModel(), nil).View()
+
+	for _, text := range []string{"🍕", "Test Title", "Test message", "Mantra header", "\"Ship it\"", "Order", "Skip", "Snooze"} {
+		if !strings.Contains(view, text) {
+			t.Errorf("Expected view to show '%s'", text)
+		}
+	}
+}
+
+func TestViewShowsAttempt(t *testing.T) {
+	m := testModel()
+	m.Attempt, m.Attempts = 2, 3
+	if view := NewReminder(m, nil).View(); !strings.Contains(view, "Test message · reminder 2 of 3") {
+		t.Errorf("Expected view to show the attempt, got:\n%s", view)
+	}
+}
+
+func TestFormatCountdown(t *testing.T) {
+	tests := []struct {
+		d        time.Duration
+		expected string
+	}{
+		{0, "0:00"},
+		{-time.Second, "0:00"},
+		{59 * time.Second, "0:59"},
+		{3 * time.Minute, "3:00"},
+		{10*time.Minute + 5*time.Second, "10:05"},
+	}
+
+	for _, tt := range tests {
+		if got := formatCountdown(tt.d); got != tt.expected {
+			t.Errorf("formatCountdown(%v) = %s, expected %s", tt.d, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package windows
+
+import (
+	"fmt"
+	"sultengutt/internal/popup/model"
+)
+
+// Exit codes the popup scripts report the user's choice with. PowerShell itself
+// exits with 1 when the script fails, so the choices stay clear of it.
+const (
+	exitClosed       = 0
+	exitScriptFailed = 1
+	exitOrder        = 10
+	exitSkip         = 11
+	exitSnooze       = 12
+	exitTimeout      = 13
+	exitOrderVendor  = 20 // plus the index of the vendor picked in the script
+)
+
+// resultFromExitCode turns the exit code of the popup script shown for m into a result
+func resultFromExitCode(code int, m model.Model) (model.Result, error) {
+	if i := code - exitOrderVendor; i >= 0 && i < len(m.Vendors) {
+		return m.OrderResult(i), nil
+	}
+	switch code {
+	case exitClosed:
+		return model.Result{Action: model.ActionClose}, nil
+	case exitOrder:
+		return m.OrderResult(m.DefaultVendor), nil
+	case exitSkip:
+		return model.Result{Action: model.ActionSkip}, nil
+	case exitSnooze:
+		return model.Result{Action: model.ActionSnooze, SnoozeFor: snoozeFor(m)}, nil
+	case exitTimeout:
+		return model.Result{Action: model.ActionTimeout}, nil
+	}
+	return model.Result{Action: model.ActionClose}, fmt.Errorf("popup script failed with exit code %d", code)
+}
@@ -0,0 +1,65 @@
+package windows
+
+import (
+	"sultengutt/internal/popup/model"
+	"testing"
+	"time"
+)
+
+func TestResultFromExitCode(t *testing.T) {
+	m := model.New("https://example.com", "")
+	m.SnoozeOptions = []time.Duration{10 * time.Minute}
+
+	tests := []struct {
+		code     int
+		expected model.Result
+		wantErr  bool
+	}{
+		{exitClosed, model.Result{Action: model.ActionClose}, false},
+		{exitOrder, model.Result{Action: model.ActionOrder}, false},
+		{exitSkip, model.Result{Action: model.ActionSkip}, false},
+		{exitSnooze, model.Result{Action: model.ActionSnooze, SnoozeFor: 10 * time.Minute}, false},
+		{exitTimeout, model.Result{Action: model.ActionTimeout}, false},
+		{1, model.Result{Action: model.ActionClose}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := resultFromExitCode(tt.code, m)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("resultFromExitCode(%d) error = %v, wantErr %v", tt.code, err, tt.wantErr)
+		}
+		if got != tt.expected {
+			t.Errorf("resultFromExitCode(%d) = %+v, expected %+v", tt.code, got, tt.expected)
+		}
+	}
+}
+
+func TestResultFromExitCodeVendors(t *testing.T) {
+	m := model.New("https://wolt.com", "")
+	m.Vendors = []model.Vendor{
+		{Name: "Wolt", URL: "https://wolt.com"},
+		{Name: "Foodora", URL: "https://foodora.no"},
+	}
+	m.DefaultVendor = 1
+
+	tests := []struct {
+		code     int
+		expected model.Result
+		wantErr  bool
+	}{
+		{exitOrder, model.Result{Action: model.ActionOrder, Vendor: "Foodora"}, false},
+		{exitOrderVendor, model.Result{Action: model.ActionOrder, Vendor: "Wolt"}, false},
+		{exitOrderVendor + 1, model.Result{Action: model.ActionOrder, Vendor: "Foodora"}, false},
+		{exitOrderVendor + 2, model.Result{Action: model.ActionClose}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := resultFromExitCode(tt.code, m)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("resultFromExitCode(%d) error = %v, wantErr %v", tt.code, err, tt.wantErr)
+		}
+		if got != tt.expected {
+			t.Errorf("resultFromExitCode(%d) = %+v, expected %+v", tt.code, got, tt.expected)
+		}
+	}
+}
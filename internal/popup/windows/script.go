@@ -0,0 +1,196 @@
+package windows
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+	"sultengutt/internal/popup/model"
+	"text/template"
+	"time"
+)
+
+//go:embed popup.ps1.tmpl
+var popupTemplate string
+
+//go:embed popup_fallback.ps1.tmpl
+var fallbackTemplate string
+
+var templateFuncs = template.FuncMap{
+	"xaml":  escapeXAML,
+	"ps":    quotePowerShell,
+	"quote": func(s string) string { return "\"" + s + "\"" },
+}
+
+var (
+	popupScript    = template.Must(template.New("popup.ps1").Funcs(templateFuncs).Parse(popupTemplate))
+	fallbackScript = template.Must(template.New("popup_fallback.ps1").Funcs(templateFuncs).Parse(fallbackTemplate))
+)
+
+// scriptData is what the popup scripts are rendered with
+type scriptData struct {
+	Emoji        string
+	ImagePath    string // shown instead of the emoji when set
+	Title        string
+	Message      string
+	MantraHeader string
+	Mantra       string
+	OrderLabel   string
+	CanOrder     bool // the order button is disabled when no vendor has a usable URL
+	SkipLabel    string
+	SnoozeLabel  string // empty when snoozing is turned off
+	Hints        string // the keyboard shortcuts
+
+	Vendors       []string // labels of the vendors to pick from, empty with a single vendor
+	DefaultVendor int
+
+	TimeoutSeconds int // 0 keeps the popup open until the user answers
+
+	Palette scriptPalette
+	Scale   float32 // font scale of the theme
+
+	ExitClosed  int
+	ExitOrder   int
+	ExitSkip    int
+	ExitSnooze  int
+	ExitTimeout int
+
+	ExitOrderVendor int
+}
+
+// Size scales a font size of the popup layout with the theme
+func (d scriptData) Size(base float64) string {
+	return strconv.FormatFloat(base*float64(d.Scale), 'f', -1, 32)
+}
+
+// scriptPalette is the theme's palette for the scripts
+type scriptPalette struct {
+	Background scriptColor
+	Foreground scriptColor
+	Muted      scriptColor
+	Surface    scriptColor
+	Accent     scriptColor
+	OnAccent   scriptColor
+}
+
+// scriptColor formats a palette colour for XAML and Windows Forms
+type scriptColor color.NRGBA
+
+// Hex formats the colour as a XAML #AARRGGBB colour
+func (c scriptColor) Hex() string {
+	return fmt.Sprintf("#FF%02X%02X%02X", c.R, c.G, c.B)
+}
+
+// RGB formats the colour as the arguments of Color.FromArgb
+func (c scriptColor) RGB() string {
+	return fmt.Sprintf("%d, %d, %d", c.R, c.G, c.B)
+}
+
+// RenderScript renders the WPF popup script for a model. Its controls carry
+// accessible names and shortcut keys for screen readers, as do the fallback's.
+func RenderScript(m model.Model) (string, error) {
+	return render(popupScript, m)
+}
+
+// RenderFallbackScript renders the simpler Windows Forms popup script for a model,
+// for systems without WPF
+func RenderFallbackScript(m model.Model) (string, error) {
+	return render(fallbackScript, m)
+}
+
+func render(t *template.Template, m model.Model) (string, error) {
+	data := scriptData{
+		Emoji:          m.Emoji,
+		ImagePath:      m.ImagePath,
+		Title:          m.Title,
+		Message:        m.Subtitle(),
+		MantraHeader:   m.MantraHeader,
+		Mantra:         m.Mantra,
+		OrderLabel:     buttonLabel(m, model.ActionOrder),
+		CanOrder:       m.CanOrder(),
+		SkipLabel:      buttonLabel(m, model.ActionSkip),
+		Hints:          strings.Join(m.ShortcutHints(), "   ·   "),
+		TimeoutSeconds: int(m.Timeout.Seconds()),
+		ExitClosed:     exitClosed,
+		ExitOrder:      exitOrder,
+		ExitSkip:       exitSkip,
+		ExitSnooze:     exitSnooze,
+		ExitTimeout:    exitTimeout,
+
+		ExitOrderVendor: exitOrderVendor,
+	}
+	palette := m.Theme.Palette()
+	data.Palette = scriptPalette{
+		Background: scriptColor(palette.Background),
+		Foreground: scriptColor(palette.Foreground),
+		Muted:      scriptColor(palette.Muted),
+		Surface:    scriptColor(palette.Surface),
+		Accent:     scriptColor(palette.Accent),
+		OnAccent:   scriptColor(palette.OnAccent()),
+	}
+	data.Scale = m.Theme.Scale()
+	if len(m.Vendors) > 1 {
+		for _, v := range m.Vendors {
+			data.Vendors = append(data.Vendors, v.Label())
+		}
+		data.DefaultVendor = m.DefaultVendor
+	}
+	if len(m.SnoozeOptions) > 0 {
+		data.SnoozeLabel = model.SnoozeLabel(m.SnoozeOptions[0])
+	}
+
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", t.Name(), err)
+	}
+	return b.String(), nil
+}
+
+// escapeXAML escapes text for a XAML attribute value. Line breaks become character
+// references, so the text can't end the here-string the XAML is embedded in, and a
+// leading brace is escaped so it isn't read as a markup extension.
+func escapeXAML(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	if strings.HasPrefix(s, "{") {
+		return "{}" + b.String()
+	}
+	return b.String()
+}
+
+// quotePowerShell quotes text as a single-quoted PowerShell string, in which only
+// quotes are special. PowerShell also takes the typographic single quotes as quotes.
+func quotePowerShell(s string) string {
+	var b strings.Builder
+	b.WriteByte('\'')
+	for _, r := range s {
+		switch r {
+		case '\'', '‘', '’', '‚', '‛':
+			b.WriteRune(r)
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+// buttonLabel returns the label of the model's button for an action
+func buttonLabel(m model.Model, action model.Action) string {
+	for _, b := range m.Buttons {
+		if b.Action == action {
+			return b.Label
+		}
+	}
+	return string(action)
+}
+
+// snoozeFor returns the default snooze duration of the model
+func snoozeFor(m model.Model) time.Duration {
+	if len(m.SnoozeOptions) == 0 {
+		return model.DefaultSnoozeOptions[0]
+	}
+	return m.SnoozeOptions[0]
+}
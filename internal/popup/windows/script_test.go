@@ -0,0 +1,477 @@
+package windows
+
+import (
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+	"sultengutt/internal/popup/model"
+	"testing"
+	"time"
+)
+
+// hostile contains text that broke out of the old Sprintf based scripts
+var hostile = []string{
+	`</TextBlock><Button Content="pwned"/>`,
+	`'; Remove-Item -Recurse C:\ ; '`,
+	`$(Start-Process calc) and ${env:USERPROFILE}`,
+	"\"@\nStart-Process calc\n'@\n",
+	"Line one\r\nLine two\ttabbed",
+	"It’s a “quoted” ‘word’ & <more>",
+	"{Binding Path=Secret}",
+}
+
+func hostileModel(text string) model.Model {
+	m := model.New("https://example.com/order?a=1&b='2'", "")
+	m.Emoji = text
+	m.Title = text
+	m.Message = text
+	m.MantraHeader = text
+	m.Mantra = text
+	m.Buttons = []model.Button{
+		{Label: text, Action: model.ActionSkip},
+		{Label: text, Action: model.ActionOrder, Primary: true},
+	}
+	m.SnoozeOptions = []time.Duration{10 * time.Minute}
+	return m
+}
+
+// xamlOf extracts the XAML here-string of a rendered WPF script
+func xamlOf(t *testing.T, script string) string {
+	t.Helper()
+	start := strings.Index(script, "@'\n")
+	if start == -1 {
+		t.Fatal("Expected a single-quoted here-string")
+	}
+	rest := script[start+3:]
+	if strings.Count(rest, "\n'@") != 1 {
+		t.Fatalf("Expected the here-string to end exactly once, script:\n%s", script)
+	}
+	return rest[:strings.Index(rest, "\n'@")]
+}
+
+// namedValues returns the Text and Content attributes of the named XAML elements
+func namedValues(t *testing.T, xamlText string) map[string]string {
+	t.Helper()
+	values := make(map[string]string)
+	decoder := xml.NewDecoder(strings.NewReader(xamlText))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return values
+		}
+		if err != nil {
+			t.Fatalf("Expected valid XAML, got %v:\n%s", err, xamlText)
+		}
+		element, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		var name, value string
+		for _, attr := range element.Attr {
+			switch attr.Name.Local {
+			case "Name":
+				name = attr.Value
+			case "Text", "Content":
+				value = attr.Value
+			}
+		}
+		if name != "" {
+			values[name] = value
+		}
+	}
+}
+
+// parsePowerShellString parses a single-quoted PowerShell string and fails unless
+// it spans the whole value
+func parsePowerShellString(t *testing.T, value string) string {
+	t.Helper()
+	runes := []rune(value)
+	if len(runes) < 2 || runes[0] != '\'' {
+		t.Fatalf("Expected a single-quoted string, got %s", value)
+	}
+	isQuote := func(r rune) bool { return strings.ContainsRune("'‘’‚‛", r) }
+
+	var b strings.Builder
+	for i := 1; i < len(runes); i++ {
+		if isQuote(runes[i]) {
+			if i+1 < len(runes) && isQuote(runes[i+1]) {
+				b.WriteRune(runes[i])
+				i++
+				continue
+			}
+			if i != len(runes)-1 {
+				t.Fatalf("String ends early, the rest would run as code: %s", value)
+			}
+			return b.String()
+		}
+		b.WriteRune(runes[i])
+	}
+	t.Fatalf("Unterminated string: %s", value)
+	return ""
+}
+
+func TestEscapeXAML(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Lunch time", "Lunch time"},
+		{`a < b & "c" > 'd'`, "a &lt; b &amp; &#34;c&#34; &gt; &#39;d&#39;"},
+		{"one\r\ntwo", "one&#xD;&#xA;two"},
+		{"{Binding}", "{}{Binding}"},
+		{"not {a} markup extension", "not {a} markup extension"},
+	}
+
+	for _, tt := range tests {
+		if got := escapeXAML(tt.input); got != tt.expected {
+			t.Errorf("escapeXAML(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestQuotePowerShell(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Lunch time", "'Lunch time'"},
+		{"", "''"},
+		{"it's", "'it''s'"},
+		{"it’s", "'it’’s'"},
+		{"$(calc) `n", "'$(calc) `n'"},
+	}
+
+	for _, tt := range tests {
+		if got := quotePowerShell(tt.input); got != tt.expected {
+			t.Errorf("quotePowerShell(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestRenderScriptEscapesText(t *testing.T) {
+	for i, text := range hostile {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			m := hostileModel(text)
+			script, err := RenderScript(m)
+			if err != nil {
+				t.Fatalf("Failed to render script: %v", err)
+			}
+			if strings.Contains(script, "example.com") {
+				t.Error("Expected the order URL to stay out of the script")
+			}
+
+			// WPF reads a leading {} as an escape, the text is shown without it
+			expected := text
+			if strings.HasPrefix(text, "{") {
+				expected = "{}" + text
+			}
+
+			values := namedValues(t, xamlOf(t, script))
+			for _, name := range []string{"EmojiText", "TitleText", "MessageText", "MantraHeaderText", "SkipButton", "OrderButton"} {
+				if values[name] != expected {
+					t.Errorf("Expected %s to be %q, got %q", name, expected, values[name])
+				}
+			}
+			if values["MantraText"] != "\""+text+"\"" {
+				t.Errorf("Expected the mantra in quotes, got %q", values["MantraText"])
+			}
+			if values["SnoozeButton"] != "Snooze 10 min" {
+				t.Errorf("Expected a snooze button, got %q", values["SnoozeButton"])
+			}
+		})
+	}
+}
+
+func TestRenderFallbackScriptEscapesText(t *testing.T) {
+	labels := []string{"$emojiLabel", "$titleLabel", "$messageLabel", "$mantraHeader", "$orderButton", "$snoozeButton", "$skipButton"}
+
+	for i, text := range hostile {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			script, err := RenderFallbackScript(hostileModel(text))
+			if err != nil {
+				t.Fatalf("Failed to render script: %v", err)
+			}
+			if strings.Contains(script, "example.com") {
+				t.Error("Expected the order URL to stay out of the script")
+			}
+
+			for _, label := range labels {
+				prefix := label + ".Text = "
+				start := strings.Index(script, prefix)
+				if start == -1 {
+					t.Fatalf("Expected %s to have a text", label)
+				}
+				// the literal may span lines, it ends before the next statement
+				value := script[start+len(prefix):]
+				value = value[:strings.Index(value, "\n"+label+".Font")]
+
+				expected := text
+				if label == "$snoozeButton" {
+					expected = "Snooze 10 min"
+				}
+				if got := parsePowerShellString(t, value); got != expected {
+					t.Errorf("Expected %s to be %q, got %q", label, expected, got)
+				}
+			}
+
+			for _, label := range []string{"$orderButton", "$skipButton"} {
+				prefix := label + ".AccessibleName = "
+				start := strings.Index(script, prefix)
+				if start == -1 {
+					t.Fatalf("Expected %s to have an accessible name", label)
+				}
+				value := script[start+len(prefix):]
+				value = value[:strings.Index(value, "\n"+label+".AccessibleDescription")]
+				if got := parsePowerShellString(t, value); got != text {
+					t.Errorf("Expected the accessible name of %s to be %q, got %q", label, text, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderScriptShortcuts(t *testing.T) {
+	m := model.New("https://example.com", "")
+
+	script, err := RenderScript(m)
+	if err != nil {
+		t.Fatalf("Failed to render script: %v", err)
+	}
+	xamlText := xamlOf(t, script)
+	values := namedValues(t, xamlText)
+	if values["HintText"] != "Esc: Skip Today   ·   Enter: Order Now   ·   S: Snooze" {
+		t.Errorf("Unexpected shortcut hints %q", values["HintText"])
+	}
+	for _, expected := range []string{
+		`FocusManager.FocusedElement="{Binding ElementName=OrderButton}"`,
+		`AutomationProperties.Name="Order Now"`,
+		`AutomationProperties.AcceleratorKey="Enter"`,
+		`SizeToContent="Height"`,
+	} {
+		if !strings.Contains(xamlText, expected) {
+			t.Errorf("Expected the XAML to contain %q", expected)
+		}
+	}
+	for _, expected := range []string{
+		"'Return' { $e.Handled = $true; & $order }",
+		"'Escape' { $e.Handled = $true; & $skip }",
+		"'S' { $e.Handled = $true; & $snooze }",
+	} {
+		if !strings.Contains(script, expected) {
+			t.Errorf("Expected the script to contain %q", expected)
+		}
+	}
+
+	fallback, err := RenderFallbackScript(m)
+	if err != nil {
+		t.Fatalf("Failed to render fallback script: %v", err)
+	}
+	for _, expected := range []string{
+		"$form.AcceptButton = $orderButton",
+		"$form.CancelButton = $skipButton",
+		"$form.ActiveControl = $orderButton",
+		"$hintLabel.Text = 'Esc: Skip Today   ·   Enter: Order Now   ·   S: Snooze'",
+		`if ($e.KeyCode -eq "S")`,
+	} {
+		if !strings.Contains(fallback, expected) {
+			t.Errorf("Expected the fallback script to contain %q", expected)
+		}
+	}
+}
+
+func TestRenderScriptOutcomes(t *testing.T) {
+	m := model.New("https://example.com", "")
+	m.Timeout = 90 * time.Second
+
+	script, err := RenderScript(m)
+	if err != nil {
+		t.Fatalf("Failed to render script: %v", err)
+	}
+	for _, expected := range []string{
+		fmt.Sprintf("$script:outcome = %d\n", exitClosed),
+		fmt.Sprintf("$script:outcome = %d\n", exitOrder),
+		fmt.Sprintf("$script:outcome = %d\n", exitSkip),
+		fmt.Sprintf("$script:outcome = %d\n", exitSnooze),
+		fmt.Sprintf("$script:outcome = %d\n", exitTimeout),
+		"[TimeSpan]::FromSeconds(90)",
+		"exit $script:outcome",
+	} {
+		if !strings.Contains(script, expected) {
+			t.Errorf("Expected the script to contain %q", expected)
+		}
+	}
+}
+
+func TestRenderScriptWithoutSnoozeOrTimeout(t *testing.T) {
+	m := model.New("https://example.com", "")
+	m.SnoozeOptions = nil
+	m.Timeout = 0
+
+	for name, render := range map[string]func(model.Model) (string, error){
+		"wpf":      RenderScript,
+		"fallback": RenderFallbackScript,
+	} {
+		t.Run(name, func(t *testing.T) {
+			script, err := render(m)
+			if err != nil {
+				t.Fatalf("Failed to render script: %v", err)
+			}
+			if strings.Contains(strings.ToLower(script), "snooze") {
+				t.Error("Expected no snooze button when snoozing is turned off")
+			}
+			if strings.Contains(script, "$timer") {
+				t.Error("Expected no auto-close timer without a timeout")
+			}
+		})
+	}
+}
+
+func TestRenderScriptWithoutOrderButton(t *testing.T) {
+	m := model.New("", "")
+	m.Buttons = m.Buttons[:1]
+
+	tests := []struct {
+		name     string
+		render   func(model.Model) (string, error)
+		expected string
+	}{
+		{"wpf", RenderScript, `IsEnabled="False"`},
+		{"fallback", RenderFallbackScript, "$orderButton.Enabled = $false"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script, err := tt.render(m)
+			if err != nil {
+				t.Fatalf("Failed to render script: %v", err)
+			}
+			if !strings.Contains(script, tt.expected) {
+				t.Errorf("Expected the order button to be disabled, script:\n%s", script)
+			}
+			if strings.Contains(script, "'Return' {") {
+				t.Error("Expected Enter not to order")
+			}
+		})
+	}
+}
+
+func TestRenderScriptVendors(t *testing.T) {
+	m := model.New("https://wolt.com", "")
+	m.Vendors = []model.Vendor{
+		{Name: "Wolt", URL: "https://wolt.com"},
+		{Name: "Bella's <Pizza>", URL: "https://bella.example.com", Icon: "🍕"},
+	}
+	m.DefaultVendor = 1
+
+	script, err := RenderScript(m)
+	if err != nil {
+		t.Fatalf("Failed to render script: %v", err)
+	}
+	xamlText := xamlOf(t, script)
+	namedValues(t, xamlText)
+	for _, expected := range []string{
+		`SelectedIndex="1"`,
+		`<ComboBoxItem Content="Wolt"/>`,
+		`<ComboBoxItem Content="🍕 Bella&#39;s &lt;Pizza&gt;"/>`,
+	} {
+		if !strings.Contains(xamlText, expected) {
+			t.Errorf("Expected the XAML to contain %q", expected)
+		}
+	}
+	if expected := fmt.Sprintf("$script:outcome = %d + $vendorBox.SelectedIndex", exitOrderVendor); !strings.Contains(script, expected) {
+		t.Errorf("Expected the order outcome to include the vendor, missing %q", expected)
+	}
+
+	fallback, err := RenderFallbackScript(m)
+	if err != nil {
+		t.Fatalf("Failed to render fallback script: %v", err)
+	}
+	for _, expected := range []string{
+		"[void]$vendorBox.Items.Add('Wolt')",
+		"[void]$vendorBox.Items.Add('🍕 Bella''s <Pizza>')",
+		"$vendorBox.SelectedIndex = 1",
+		fmt.Sprintf("$script:outcome = %d + $vendorBox.SelectedIndex", exitOrderVendor),
+	} {
+		if !strings.Contains(fallback, expected) {
+			t.Errorf("Expected the fallback script to contain %q", expected)
+		}
+	}
+
+	m.Vendors = m.Vendors[:1]
+	for name, render := range map[string]func(model.Model) (string, error){
+		"wpf":      RenderScript,
+		"fallback": RenderFallbackScript,
+	} {
+		script, err := render(m)
+		if err != nil {
+			t.Fatalf("Failed to render %s script: %v", name, err)
+		}
+		if strings.Contains(script, "$vendorBox") {
+			t.Errorf("Expected no vendor picker in the %s script with a single vendor", name)
+		}
+	}
+}
+
+func TestRenderScriptImage(t *testing.T) {
+	m := model.New("https://example.com", "")
+	m.ImagePath = `C:\Users\o'brien\logo.png`
+
+	script, err := RenderScript(m)
+	if err != nil {
+		t.Fatalf("Failed to render script: %v", err)
+	}
+	values := namedValues(t, xamlOf(t, script))
+	if _, ok := values["EmojiText"]; ok {
+		t.Error("Expected the image to replace the emoji")
+	}
+	if !strings.Contains(xamlOf(t, script), `Source="C:\Users\o&#39;brien\logo.png"`) {
+		t.Error("Expected the image source to be the escaped image path")
+	}
+
+	fallback, err := RenderFallbackScript(m)
+	if err != nil {
+		t.Fatalf("Failed to render fallback script: %v", err)
+	}
+	if !strings.Contains(fallback, `[System.Drawing.Image]::FromFile('C:\Users\o''brien\logo.png')`) {
+		t.Error("Expected the fallback script to load the quoted image path")
+	}
+}
+
+func TestRenderScriptTheme(t *testing.T) {
+	m := model.New("https://example.com", "")
+	m.Theme = model.Theme{Mode: model.ThemeLight, Accent: color.NRGBA{0xFF, 0x6B, 0x6B, 0xFF}, FontScale: 1.5}
+
+	script, err := RenderScript(m)
+	if err != nil {
+		t.Fatalf("Failed to render script: %v", err)
+	}
+	xamlText := xamlOf(t, script)
+	for _, expected := range []string{
+		`Background="#FFF3F3F3"`, // light background
+		`Foreground="#FF1E1E1E"`, // light foreground
+		`Background="#FFFF6B6B"`, // accent on the order button
+		`FontSize="72"`,          // the emoji at 1.5 times 48
+	} {
+		if !strings.Contains(xamlText, expected) {
+			t.Errorf("Expected the XAML to contain %s", expected)
+		}
+	}
+	if strings.Contains(xamlText, "#FF2D2D30") {
+		t.Error("Expected no dark background in the light theme")
+	}
+
+	fallback, err := RenderFallbackScript(m)
+	if err != nil {
+		t.Fatalf("Failed to render fallback script: %v", err)
+	}
+	for _, expected := range []string{
+		"$form.BackColor = [System.Drawing.Color]::FromArgb(243, 243, 243)",
+		"$orderButton.BackColor = [System.Drawing.Color]::FromArgb(255, 107, 107)",
+		`System.Drawing.Font("Segoe UI Emoji", 54)`,
+	} {
+		if !strings.Contains(fallback, expected) {
+			t.Errorf("Expected the fallback script to contain %s", expected)
+		}
+	}
+}
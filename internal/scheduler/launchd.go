@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// launchdSnoozer schedules every snooze as its own launchd job. A snoozed run that
+// snoozes again, is shown again after a timeout or waits for a meeting must not unload
+// the job it runs in, as launchd would stop it before the new job is loaded.
+type launchdSnoozer struct {
+	agentsDir    string // ~/Library/LaunchAgents
+	execPath     string
+	runningLabel string // label of the launchd job running this process, if any
+	launchctl    func(args ...string) error
+}
+
+// Snooze loads a job for when the snooze ends, then removes the earlier ones
+func (l *launchdSnoozer) Snooze(at time.Time) error {
+	label := snoozeJobLabel(at)
+	plistPath := filepath.Join(l.agentsDir, label+".plist")
+	if err := os.WriteFile(plistPath, []byte(createSnoozePlist(label, l.execPath, at)), 0644); err != nil {
+		return fmt.Errorf("failed to write snooze plist file: %w", err)
+	}
+	if err := l.launchctl("load", plistPath); err != nil {
+		return fmt.Errorf("failed to schedule snooze: %w", err)
+	}
+	return l.removeJobs(label)
+}
+
+// CancelSnooze removes the snooze jobs
+func (l *launchdSnoozer) CancelSnooze() error {
+	return l.removeJobs("")
+}
+
+// removeJobs removes the snooze jobs but the one labelled keep. The job running this
+// process is not unloaded, only its file is removed, so launchd forgets it at the
+// next login.
+func (l *launchdSnoozer) removeJobs(keep string) error {
+	// the pattern also matches the single snooze job of older versions
+	paths, err := filepath.Glob(filepath.Join(l.agentsDir, snoozeLabel+"*.plist"))
+	if err != nil {
+		return fmt.Errorf("failed to find snooze jobs: %w", err)
+	}
+	for _, plistPath := range paths {
+		label := strings.TrimSuffix(filepath.Base(plistPath), ".plist")
+		if label == keep {
+			continue
+		}
+		if label != l.runningLabel {
+			// the job may already be unloaded, so only removing the file matters
+			l.launchctl("unload", plistPath)
+		}
+		if err := os.Remove(plistPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove snooze plist file: %w", err)
+		}
+	}
+	return nil
+}
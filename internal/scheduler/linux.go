@@ -0,0 +1,120 @@
+//go:build linux
+
+package scheduler
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sultengutt/internal/config"
+	"time"
+)
+
+// SystemdScheduler runs the reminder from a systemd user timer, which starts it in the
+// user's session so the popup can reach the desktop
+type SystemdScheduler struct {
+	installOptions config.InstallOptions
+	execPath       string
+}
+
+func (s *SystemdScheduler) RegisterTask() error {
+	dir, err := systemdUserDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create systemd user directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, systemdUnit+".service"), []byte(createServiceUnit(s.execPath)), 0644); err != nil {
+		return fmt.Errorf("failed to write service file: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, systemdUnit+".timer"), []byte(createTimerUnit(s.installOptions)), 0644); err != nil {
+		return fmt.Errorf("failed to write timer file: %w", err)
+	}
+
+	if err := systemctl("daemon-reload"); err != nil {
+		return fmt.Errorf("failed to register task: %w", err)
+	}
+	if err := systemctl("enable", "--now", systemdUnit+".timer"); err != nil {
+		return fmt.Errorf("failed to register task: %w", err)
+	}
+	return nil
+}
+
+func (s *SystemdScheduler) UnregisterTask() error {
+	exists, err := s.TaskExists()
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	if err := systemctl("disable", "--now", systemdUnit+".timer"); err != nil {
+		return fmt.Errorf("failed to unregister task: %w", err)
+	}
+	dir, err := systemdUserDir()
+	if err != nil {
+		return err
+	}
+	for _, unit := range []string{systemdUnit + ".timer", systemdUnit + ".service"} {
+		if err := os.Remove(filepath.Join(dir, unit)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", unit, err)
+		}
+	}
+	return systemctl("daemon-reload")
+}
+
+func (s *SystemdScheduler) TaskExists() (bool, error) {
+	dir, err := systemdUserDir()
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(filepath.Join(dir, systemdUnit+".timer")); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Snooze starts a transient systemd timer for when the snooze ends, replacing any
+// earlier one. Unlike a process waiting for the snooze, the timer isn't stopped along
+// with the service of the scheduled run.
+func (s *SystemdScheduler) Snooze(at time.Time) error {
+	if err := s.CancelSnooze(); err != nil {
+		return err
+	}
+	out, err := exec.Command("systemd-run", createSnoozeRun(s.execPath, at)...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to schedule snooze: %w\n%s", err, out)
+	}
+	return nil
+}
+
+// CancelSnooze stops the pending snooze timers. The service of a snoozed run that is
+// snoozing again keeps running.
+func (s *SystemdScheduler) CancelSnooze() error {
+	if err := systemctl("stop", snoozeUnitPrefix+"*.timer"); err != nil {
+		return fmt.Errorf("failed to cancel snooze: %w", err)
+	}
+	return nil
+}
+
+func systemdUserDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user config directory: %w", err)
+	}
+	return filepath.Join(configDir, "systemd", "user"), nil
+}
+
+func systemctl(args ...string) error {
+	out, err := exec.Command("systemctl", append([]string{"--user"}, args...)...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("systemctl %v: %w\n%s", args, err, out)
+	}
+	return nil
+}
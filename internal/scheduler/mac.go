@@ -82,8 +82,11 @@ func (m *MacScheduler) TaskExists() (bool, error) {
 	return true, nil
 }
 
-func (m *MacScheduler) Snooze() error {
-	// TODO: Implement snooze functionality
+func launchctl(args ...string) error {
+	out, err := exec.Command("launchctl", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("launchctl %v: %w\n%s", args, err, out)
+	}
 	return nil
 }
 
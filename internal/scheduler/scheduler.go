@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"sultengutt/internal/config"
+	"time"
 )
 
 type Scheduler interface {
@@ -15,3 +16,16 @@ type Scheduler interface {
 func NewScheduler(options config.InstallOptions, configDir string) Scheduler {
 	return newScheduler(options, configDir)
 }
+
+// Snoozer schedules a one-off reminder for when a snooze ends. The one-off run
+// checks the snooze in the config, so a cancelled snooze that still fires does nothing.
+type Snoozer interface {
+	Snooze(at time.Time) error
+	CancelSnooze() error
+}
+
+// NewSnoozer creates a platform-specific snoozer
+// The actual implementation is in scheduler_darwin.go, scheduler_windows.go and scheduler_linux.go
+func NewSnoozer(configDir string) (Snoozer, error) {
+	return newSnoozer(configDir)
+}
@@ -5,6 +5,8 @@ package scheduler
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"sultengutt/internal/config"
 	"sultengutt/internal/utils"
 )
@@ -16,3 +18,17 @@ func newScheduler(options config.InstallOptions, configDir string) Scheduler {
 	}
 	return &MacScheduler{execPath: execPath, installOptions: options}
 }
+
+func newSnoozer(configDir string) (Snoozer, error) {
+	execPath, err := utils.ResolveExecutablePath("sultengutt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve executable path for Sultengutt: %w", err)
+	}
+	homeDir, _ := os.UserHomeDir()
+	return &launchdSnoozer{
+		agentsDir:    filepath.Join(homeDir, "Library", "LaunchAgents"),
+		execPath:     execPath,
+		runningLabel: os.Getenv("XPC_SERVICE_NAME"), // set by launchd to the job's label
+		launchctl:    launchctl,
+	}, nil
+}
@@ -0,0 +1,25 @@
+//go:build linux
+
+package scheduler
+
+import (
+	"fmt"
+	"sultengutt/internal/config"
+	"sultengutt/internal/utils"
+)
+
+func newScheduler(options config.InstallOptions, configDir string) Scheduler {
+	execPath, err := utils.ResolveExecutablePath("sultengutt")
+	if err != nil {
+		panic(fmt.Errorf("failed to resolve executable path for Sultengutt: %w", err))
+	}
+	return &SystemdScheduler{execPath: execPath, installOptions: options}
+}
+
+func newSnoozer(configDir string) (Snoozer, error) {
+	execPath, err := utils.ResolveExecutablePath("sultengutt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve executable path for Sultengutt: %w", err)
+	}
+	return &SystemdScheduler{execPath: execPath}, nil
+}
@@ -1,8 +1,13 @@
 package scheduler
 
 import (
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"sultengutt/internal/config"
 	"testing"
+	"time"
 )
 
 func TestNewScheduler(t *testing.T) {
@@ -256,3 +261,151 @@ func TestSchedulerOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateSnoozePlist(t *testing.T) {
+	at := time.Date(2026, 10, 19, 16, 45, 0, 0, time.Local)
+	plist := createSnoozePlist(snoozeJobLabel(at), "/usr/local/bin/sultengutt", at)
+
+	for _, expected := range []string{
+		"<string>" + snoozeLabel + "." + strconv.FormatInt(at.Unix(), 10) + "</string>",
+		"<string>/usr/local/bin/sultengutt</string>",
+		"<string>--snoozed</string>",
+		"<key>Month</key>\n\t\t<integer>10</integer>",
+		"<key>Day</key>\n\t\t<integer>19</integer>",
+		"<key>Hour</key>\n\t\t<integer>16</integer>",
+		"<key>Minute</key>\n\t\t<integer>45</integer>",
+	} {
+		if !strings.Contains(plist, expected) {
+			t.Errorf("Expected plist to contain %q", expected)
+		}
+	}
+}
+
+func TestCreateSnoozeTask(t *testing.T) {
+	at := time.Date(2026, 10, 19, 9, 5, 0, 0, time.Local)
+	task := createSnoozeTaskXML(at)
+
+	for _, expected := range []string{
+		"<StartBoundary>2026-10-19T09:05:00</StartBoundary>",
+		"<Command>sultengutt</Command>",
+		"<Arguments>execute --snoozed</Arguments>",
+	} {
+		if !strings.Contains(task, expected) {
+			t.Errorf("Expected task to contain %q", expected)
+		}
+	}
+
+	args := strings.Join(createSnoozeTask(`C:\Users\tobias\.sultengutt\snooze_task.xml`), " ")
+	for _, expected := range []string{"/tn " + snoozeTaskName, `/xml C:\Users\tobias\.sultengutt\snooze_task.xml`, "/f"} {
+		if !strings.Contains(args, expected) {
+			t.Errorf("Expected task arguments to contain %q, got %s", expected, args)
+		}
+	}
+}
+
+func TestEncodeUTF16(t *testing.T) {
+	got := encodeUTF16("Aø")
+	expected := []byte{0xFF, 0xFE, 'A', 0x00, 0xF8, 0x00}
+	if string(got) != string(expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestCreateSystemdUnits(t *testing.T) {
+	service := createServiceUnit("/usr/local/bin/sultengutt")
+	if !strings.Contains(service, `ExecStart="/usr/local/bin/sultengutt" execute`) {
+		t.Errorf("Expected the service to run the reminder, got:\n%s", service)
+	}
+
+	timer := createTimerUnit(config.InstallOptions{Days: []string{"Monday", "Friday"}, Hour: "9:05"})
+	if !strings.Contains(timer, "OnCalendar=Mon,Fri *-*-* 09:05:00") {
+		t.Errorf("Expected the timer to fire on Monday and Friday at 09:05, got:\n%s", timer)
+	}
+	if !strings.Contains(timer, "WantedBy=timers.target") {
+		t.Errorf("Expected the timer to be installable, got:\n%s", timer)
+	}
+}
+
+func TestCreateSnoozeRun(t *testing.T) {
+	at := time.Date(2026, 10, 19, 16, 45, 0, 0, time.FixedZone("CEST", 2*60*60))
+	args := strings.Join(createSnoozeRun("/usr/local/bin/sultengutt", at), " ")
+
+	for _, expected := range []string{
+		"--user",
+		"--unit=" + snoozeUnitPrefix + "1792421100",
+		"--on-calendar=2026-10-19 14:45:00 UTC",
+		"/usr/local/bin/sultengutt execute --snoozed --at 1792421100",
+	} {
+		if !strings.Contains(args, expected) {
+			t.Errorf("Expected systemd-run arguments to contain %q, got %s", expected, args)
+		}
+	}
+}
+
+// fakeLaunchd keeps track of the loaded jobs like launchd, stopping the running one
+// when it is unloaded
+type fakeLaunchd struct {
+	loaded  []string
+	running string
+	stopped bool
+}
+
+func (f *fakeLaunchd) launchctl(args ...string) error {
+	label := strings.TrimSuffix(filepath.Base(args[1]), ".plist")
+	switch args[0] {
+	case "load":
+		f.loaded = append(f.loaded, label)
+	case "unload":
+		f.loaded = slices.DeleteFunc(f.loaded, func(l string) bool { return l == label })
+		f.stopped = f.stopped || label == f.running
+	}
+	return nil
+}
+
+func TestLaunchdSnoozer(t *testing.T) {
+	dir := t.TempDir()
+	launchd := &fakeLaunchd{}
+	first := time.Date(2026, 10, 19, 16, 45, 0, 0, time.Local)
+	second := first.Add(30 * time.Minute)
+	newSnoozer := func(running string) *launchdSnoozer {
+		return &launchdSnoozer{agentsDir: dir, execPath: "/usr/local/bin/sultengutt", runningLabel: running, launchctl: launchd.launchctl}
+	}
+	jobs := func() []string {
+		paths, _ := filepath.Glob(filepath.Join(dir, "*.plist"))
+		var labels []string
+		for _, p := range paths {
+			labels = append(labels, strings.TrimSuffix(filepath.Base(p), ".plist"))
+		}
+		return labels
+	}
+
+	if err := newSnoozer("").Snooze(first); err != nil {
+		t.Fatalf("Failed to snooze: %v", err)
+	}
+	if got := jobs(); !slices.Equal(got, []string{snoozeJobLabel(first)}) || !slices.Equal(launchd.loaded, got) {
+		t.Fatalf("Expected the job of the first snooze, got files %v and loaded %v", got, launchd.loaded)
+	}
+
+	// the snoozed run snoozes again
+	launchd.running = snoozeJobLabel(first)
+	if err := newSnoozer(launchd.running).Snooze(second); err != nil {
+		t.Fatalf("Failed to snooze from the snoozed run: %v", err)
+	}
+	if launchd.stopped {
+		t.Error("Expected the snoozed run not to unload its own job")
+	}
+	if got := jobs(); !slices.Equal(got, []string{snoozeJobLabel(second)}) {
+		t.Errorf("Expected only the job of the second snooze, got %v", got)
+	}
+	if !slices.Contains(launchd.loaded, snoozeJobLabel(second)) {
+		t.Errorf("Expected the second snooze to be loaded, got %v", launchd.loaded)
+	}
+
+	launchd.running = ""
+	if err := newSnoozer("").CancelSnooze(); err != nil {
+		t.Fatalf("Failed to cancel snooze: %v", err)
+	}
+	if got := jobs(); len(got) != 0 || slices.Contains(launchd.loaded, snoozeJobLabel(second)) {
+		t.Errorf("Expected no snooze jobs, got files %v and loaded %v", got, launchd.loaded)
+	}
+}
@@ -20,3 +20,11 @@ func newScheduler(options config.InstallOptions, configDir string) Scheduler {
 	}
 	return &WindowsScheduler{execPath: execPath, installOptions: options, schedulerExecPath: schTask, configDir: configDir}
 }
+
+func newSnoozer(configDir string) (Snoozer, error) {
+	schTask, err := utils.ResolveExecutablePath("schtasks")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find schtasks (Windows): %w", err)
+	}
+	return &WindowsScheduler{schedulerExecPath: schTask, configDir: configDir}, nil
+}
@@ -0,0 +1,108 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf16"
+)
+
+const (
+	snoozeLabel    = "no.tobias.sultengutt.snooze"
+	snoozeTaskName = "Sultengutt Snooze"
+	snoozeTaskFile = "snooze_task.xml"
+)
+
+// snoozeJobLabel is the label of the launchd job for the snooze ending at the given
+// time. Every snooze has its own job, see launchdSnoozer.
+func snoozeJobLabel(at time.Time) string {
+	return fmt.Sprintf("%s.%d", snoozeLabel, at.Unix())
+}
+
+// createSnoozePlist creates a launchd job running the snoozed reminder at the given time.
+// launchd has no one-off jobs, so the job stays until the next snooze replaces it; should
+// it fire again a year later, the run finds no snooze in the config and does nothing.
+func createSnoozePlist(label, execPath string, at time.Time) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>%s</string>
+	<key>ProgramArguments</key>
+	<array>
+		<string>%s</string>
+		<string>execute</string>
+		<string>--snoozed</string>
+	</array>
+	<key>StartCalendarInterval</key>
+	<dict>
+		<key>Month</key>
+		<integer>%d</integer>
+		<key>Day</key>
+		<integer>%d</integer>
+		<key>Hour</key>
+		<integer>%d</integer>
+		<key>Minute</key>
+		<integer>%d</integer>
+	</dict>
+	<key>RunAtLoad</key>
+	<false/>
+</dict>
+</plist>`, label, execPath, int(at.Month()), at.Day(), at.Hour(), at.Minute())
+}
+
+// createSnoozeTaskXML creates the definition of a one-time task running the snoozed
+// reminder at the given time. Unlike schtasks' /sd, whose date format follows the
+// system locale, the start is given in ISO 8601.
+func createSnoozeTaskXML(at time.Time) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-16"?>
+<Task version="1.2" xmlns="http://schemas.microsoft.com/windows/2004/02/mit/task">
+  <RegistrationInfo>
+    <Description>Shows the snoozed Sultengutt reminder</Description>
+  </RegistrationInfo>
+  <Triggers>
+    <TimeTrigger>
+      <StartBoundary>%s</StartBoundary>
+      <Enabled>true</Enabled>
+    </TimeTrigger>
+  </Triggers>
+  <Principals>
+    <Principal id="Author">
+      <LogonType>InteractiveToken</LogonType>
+      <RunLevel>LeastPrivilege</RunLevel>
+    </Principal>
+  </Principals>
+  <Settings>
+    <MultipleInstancesPolicy>IgnoreNew</MultipleInstancesPolicy>
+    <DisallowStartIfOnBatteries>false</DisallowStartIfOnBatteries>
+    <StopIfGoingOnBatteries>false</StopIfGoingOnBatteries>
+    <Enabled>true</Enabled>
+  </Settings>
+  <Actions Context="Author">
+    <Exec>
+      <Command>sultengutt</Command>
+      <Arguments>execute --snoozed</Arguments>
+    </Exec>
+  </Actions>
+</Task>
+`, at.Format("2006-01-02T15:04:05"))
+}
+
+// createSnoozeTask returns the schtasks arguments registering the snooze task from its
+// definition in xmlPath
+func createSnoozeTask(xmlPath string) []string {
+	return []string{"/create",
+		"/tn", snoozeTaskName,
+		"/xml", xmlPath,
+		"/f"}
+}
+
+// encodeUTF16 encodes s as UTF-16 with a byte order mark, the encoding schtasks reads
+// task definitions in
+func encodeUTF16(s string) []byte {
+	out := []byte{0xFF, 0xFE}
+	for _, u := range utf16.Encode([]rune(s)) {
+		out = append(out, byte(u), byte(u>>8))
+	}
+	return out
+}
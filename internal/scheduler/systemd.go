@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sultengutt/internal/config"
+	"time"
+)
+
+const (
+	systemdUnit      = "sultengutt"
+	snoozeUnitPrefix = "sultengutt-snooze-"
+)
+
+// createServiceUnit creates the systemd user service running the reminder
+func createServiceUnit(execPath string) string {
+	return fmt.Sprintf(`[Unit]
+Description=Sultengutt dinner reminder
+
+[Service]
+Type=oneshot
+ExecStart="%s" execute
+`, execPath)
+}
+
+// createTimerUnit creates the systemd user timer starting the reminder service on the
+// scheduled days
+func createTimerUnit(options config.InstallOptions) string {
+	hour, minute, _ := strings.Cut(options.Hour, ":")
+	hourInt, _ := strconv.Atoi(hour)
+	minuteInt, _ := strconv.Atoi(minute)
+
+	var days []string
+	for _, day := range options.Days {
+		days = append(days, day[0:3]) // systemd accepts weekdays like Mon,Tue
+	}
+	return fmt.Sprintf(`[Unit]
+Description=Sultengutt dinner reminder schedule
+
+[Timer]
+OnCalendar=%s *-*-* %02d:%02d:00
+
+[Install]
+WantedBy=timers.target
+`, strings.Join(days, ","), hourInt, minuteInt)
+}
+
+// createSnoozeRun creates the systemd-run arguments for a transient timer running the
+// snoozed reminder at the given time. Each snooze gets its own unit, so a snoozed run
+// can snooze again while its own service is still active. The timer follows the wall
+// clock, so a snooze that ended while the machine was suspended fires on resume.
+func createSnoozeRun(execPath string, at time.Time) []string {
+	unix := strconv.FormatInt(at.Unix(), 10)
+	return []string{
+		"--user",
+		"--unit=" + snoozeUnitPrefix + unix,
+		"--on-calendar=" + at.UTC().Format("2006-01-02 15:04:05") + " UTC",
+		"--timer-property=AccuracySec=1s",
+		"--collect",
+		execPath, "execute", "--snoozed", "--at", unix,
+	}
+}
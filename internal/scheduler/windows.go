@@ -5,10 +5,12 @@ package scheduler
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"sultengutt/internal/config"
-	win "sultengutt/internal/popup/windows"
+	"time"
 )
 
 type WindowsScheduler struct {
@@ -19,8 +21,11 @@ type WindowsScheduler struct {
 }
 
 func (w *WindowsScheduler) RegisterTask() error {
-	// Create the modern popup script
-	_, err := win.GenerateWindowsScript(w.configDir, w.installOptions.SiteLink)
+	// the popup script is rendered on every run now, remove the one older versions
+	// generated at install time
+	if err := os.Remove(filepath.Join(w.configDir, "popup.ps1")); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove old popup script: %w", err)
+	}
 
 	args := w.createTask()
 	cmd := exec.Command(w.schedulerExecPath, args...)
@@ -61,6 +66,35 @@ func (w *WindowsScheduler) TaskExists() (bool, error) {
 	return true, nil
 }
 
+// Snooze creates a one-time task for when the snooze ends, replacing any earlier one
+func (w *WindowsScheduler) Snooze(at time.Time) error {
+	xmlPath := filepath.Join(w.configDir, snoozeTaskFile)
+	if err := os.WriteFile(xmlPath, encodeUTF16(createSnoozeTaskXML(at)), 0644); err != nil {
+		return fmt.Errorf("failed to write snooze task: %w", err)
+	}
+	defer os.Remove(xmlPath)
+
+	cmd := exec.Command(w.schedulerExecPath, createSnoozeTask(xmlPath)...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to schedule snooze: %w\n%s", err, out)
+	}
+	return nil
+}
+
+// CancelSnooze deletes the snooze task if there is one
+func (w *WindowsScheduler) CancelSnooze() error {
+	if err := exec.Command(w.schedulerExecPath, "/query", "/tn", snoozeTaskName).Run(); err != nil {
+		return nil
+	}
+	cmd := exec.Command(w.schedulerExecPath, "/delete", "/tn", snoozeTaskName, "/f")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to cancel snooze: %w\n%s", err, out)
+	}
+	return nil
+}
+
 func (w *WindowsScheduler) createTask() []string {
 	var days []string
 	for _, day := range w.installOptions.Days {
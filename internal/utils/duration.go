@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationPartRegex = regexp.MustCompile(`(\d+)\s*([a-z]+)`)
+	isoDurationRegex  = regexp.MustCompile(`^p(?:(\d+)y)?(?:(\d+)m)?(?:(\d+)w)?(?:(\d+)d)?(?:t(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s)?)?$`)
+)
+
+// Duration is a calendar-aware duration. Months and days are added to dates on the
+// calendar, so a month is a calendar month and a day keeps the time of day across
+// DST changes, while Clock is an exact amount of time.
+type Duration struct {
+	Months int
+	Days   int
+	Clock  time.Duration
+}
+
+// IsSubDay reports whether the duration is shorter than a calendar day
+func (d Duration) IsSubDay() bool {
+	return d.Months == 0 && d.Days == 0 && d.Clock < 24*time.Hour
+}
+
+// AddTo adds the duration to t. Months that overflow the target month end on its
+// last day, so January 31 plus one month is the end of February.
+func (d Duration) AddTo(t time.Time) time.Time {
+	if d.Months != 0 {
+		firstOfMonth := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+		target := firstOfMonth.AddDate(0, d.Months, 0)
+		lastDay := target.AddDate(0, 1, -1).Day()
+		t = target.AddDate(0, 0, min(t.Day(), lastDay)-1)
+	}
+	return t.AddDate(0, 0, d.Days).Add(d.Clock)
+}
+
+// ParseDuration parses duration strings in various formats: a single amount
+// ("30 minutes", "2h"), compound amounts ("1 week 3 days", "2w3d", "1 day and 2 hours")
+// or ISO-8601 ("P1W3D", "PT30M")
+func ParseDuration(input interface{}) (Duration, error) {
+	var durationStr string
+
+	// Handle both string and []string inputs
+	switch v := input.(type) {
+	case string:
+		durationStr = v
+	case []string:
+		if len(v) < 1 {
+			return Duration{}, fmt.Errorf("invalid duration format")
+		}
+		durationStr = strings.Join(v, " ")
+	default:
+		return Duration{}, fmt.Errorf("invalid input type")
+	}
+
+	durationStr = strings.ToLower(strings.TrimSpace(durationStr))
+	if durationStr == "" {
+		return Duration{}, fmt.Errorf("invalid duration format")
+	}
+
+	var d Duration
+	var err error
+	if strings.HasPrefix(durationStr, "p") {
+		d, err = parseISODuration(durationStr)
+	} else {
+		d, err = parseCompoundDuration(durationStr)
+	}
+	if err != nil {
+		return Duration{}, err
+	}
+
+	if d == (Duration{}) {
+		return Duration{}, fmt.Errorf("duration must be longer than zero")
+	}
+	return d, nil
+}
+
+func parseCompoundDuration(durationStr string) (Duration, error) {
+	matches := durationPartRegex.FindAllStringSubmatch(durationStr, -1)
+	if matches == nil {
+		return Duration{}, fmt.Errorf("invalid duration format")
+	}
+
+	// anything between the parts other than separators means the input is malformed
+	rest := strings.ReplaceAll(durationPartRegex.ReplaceAllString(durationStr, " "), ",", " ")
+	for _, word := range strings.Fields(rest) {
+		if word != "and" {
+			return Duration{}, fmt.Errorf("invalid duration format")
+		}
+	}
+
+	var d Duration
+	for _, match := range matches {
+		amount, err := strconv.Atoi(match[1])
+		if err != nil {
+			return Duration{}, fmt.Errorf("invalid number: %s", match[1])
+		}
+
+		// Map all unit variations to duration
+		switch unit := match[2]; unit {
+		case "m", "min", "mins", "minute", "minutes":
+			d.Clock += time.Duration(amount) * time.Minute
+		case "h", "hr", "hrs", "hour", "hours":
+			d.Clock += time.Duration(amount) * time.Hour
+		case "d", "day", "days":
+			d.Days += amount
+		case "w", "week", "weeks":
+			d.Days += amount * 7
+		case "mo", "month", "months":
+			d.Months += amount
+		default:
+			return Duration{}, fmt.Errorf("unsupported time unit: %s", unit)
+		}
+	}
+	return d, nil
+}
+
+func parseISODuration(durationStr string) (Duration, error) {
+	match := isoDurationRegex.FindStringSubmatch(durationStr)
+	if match == nil || durationStr == "p" || strings.HasSuffix(durationStr, "t") {
+		return Duration{}, fmt.Errorf("invalid ISO-8601 duration: %s", strings.ToUpper(durationStr))
+	}
+
+	amount := func(s string) int {
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+	return Duration{
+		Months: amount(match[1])*12 + amount(match[2]),
+		Days:   amount(match[3])*7 + amount(match[4]),
+		Clock: time.Duration(amount(match[5]))*time.Hour +
+			time.Duration(amount(match[6]))*time.Minute +
+			time.Duration(amount(match[7]))*time.Second,
+	}, nil
+}
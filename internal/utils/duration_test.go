@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected Duration
+		hasError bool
+	}{
+		// String inputs
+		{"30 minutes", "30 minutes", Duration{Clock: 30 * time.Minute}, false},
+		{"30m", "30m", Duration{Clock: 30 * time.Minute}, false},
+		{"2 hours", "2 hours", Duration{Clock: 2 * time.Hour}, false},
+		{"2h", "2h", Duration{Clock: 2 * time.Hour}, false},
+		{"1 day", "1 day", Duration{Days: 1}, false},
+		{"1d", "1d", Duration{Days: 1}, false},
+		{"2 weeks", "2 weeks", Duration{Days: 14}, false},
+		{"1 month", "1 month", Duration{Months: 1}, false},
+		{"1mo", "1mo", Duration{Months: 1}, false},
+		{"uppercase", "2 Hours", Duration{Clock: 2 * time.Hour}, false},
+
+		// Plural forms
+		{"5 mins", "5 mins", Duration{Clock: 5 * time.Minute}, false},
+		{"3 hrs", "3 hrs", Duration{Clock: 3 * time.Hour}, false},
+		{"2 days", "2 days", Duration{Days: 2}, false},
+
+		// Compound forms
+		{"1 week 3 days", "1 week 3 days", Duration{Days: 10}, false},
+		{"2w3d", "2w3d", Duration{Days: 17}, false},
+		{"with and", "1 day and 2 hours", Duration{Days: 1, Clock: 2 * time.Hour}, false},
+		{"with comma", "1 month, 2 weeks", Duration{Months: 1, Days: 14}, false},
+		{"1h30m", "1h30m", Duration{Clock: 90 * time.Minute}, false},
+		{"repeated unit", "1 day 1 day", Duration{Days: 2}, false},
+
+		// ISO-8601
+		{"P1W3D", "P1W3D", Duration{Days: 10}, false},
+		{"PT30M", "PT30M", Duration{Clock: 30 * time.Minute}, false},
+		{"P1M", "P1M", Duration{Months: 1}, false},
+		{"P1Y", "P1Y", Duration{Months: 12}, false},
+		{"P1Y2M", "P1Y2M", Duration{Months: 14}, false},
+		{"P1DT12H", "P1DT12H", Duration{Days: 1, Clock: 12 * time.Hour}, false},
+		{"lowercase iso", "p2d", Duration{Days: 2}, false},
+
+		// String slice inputs
+		{"slice input", []string{"30", "minutes"}, Duration{Clock: 30 * time.Minute}, false},
+		{"slice input 2", []string{"2", "hours"}, Duration{Clock: 2 * time.Hour}, false},
+		{"compound slice", []string{"1", "week", "3", "days"}, Duration{Days: 10}, false},
+
+		// Error cases
+		{"invalid format", "invalid", Duration{}, true},
+		{"empty string", "", Duration{}, true},
+		{"negative number", "-5 hours", Duration{}, true},
+		{"no number", "hours", Duration{}, true},
+		{"unsupported unit", "5 years", Duration{}, true},
+		{"zero", "0 days", Duration{}, true},
+		{"trailing garbage", "1 day or so", Duration{}, true},
+		{"empty iso", "P", Duration{}, true},
+		{"empty iso time", "P1DT", Duration{}, true},
+		{"iso wrong order", "P3D1W", Duration{}, true},
+		{"empty slice", []string{}, Duration{}, true},
+		{"invalid type", 123, Duration{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseDuration(tt.input)
+
+			if tt.hasError {
+				if err == nil {
+					t.Errorf("Expected error for input %v, but got none", tt.input)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error for input %v: %v", tt.input, err)
+				return
+			}
+
+			if result != tt.expected {
+				t.Errorf("For input %v, expected %+v, got %+v", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDurationAddTo(t *testing.T) {
+	oslo, err := time.LoadLocation("Europe/Oslo")
+	if err != nil {
+		t.Fatalf("Failed to load zone: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		duration Duration
+		expected time.Time
+	}{
+		{"month", time.Date(2026, 10, 14, 16, 0, 0, 0, time.UTC), Duration{Months: 1}, time.Date(2026, 11, 14, 16, 0, 0, 0, time.UTC)},
+		{"month end clamps", time.Date(2026, 1, 31, 16, 0, 0, 0, time.UTC), Duration{Months: 1}, time.Date(2026, 2, 28, 16, 0, 0, 0, time.UTC)},
+		{"leap year", time.Date(2028, 1, 31, 16, 0, 0, 0, time.UTC), Duration{Months: 1}, time.Date(2028, 2, 29, 16, 0, 0, 0, time.UTC)},
+		{"across year", time.Date(2026, 12, 15, 16, 0, 0, 0, time.UTC), Duration{Months: 2}, time.Date(2027, 2, 15, 16, 0, 0, 0, time.UTC)},
+		{"day across DST keeps wall clock", time.Date(2026, 3, 28, 16, 0, 0, 0, oslo), Duration{Days: 1}, time.Date(2026, 3, 29, 16, 0, 0, 0, oslo)},
+		{"hours across DST are exact", time.Date(2026, 3, 28, 16, 0, 0, 0, oslo), Duration{Clock: 24 * time.Hour}, time.Date(2026, 3, 29, 17, 0, 0, 0, oslo)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.duration.AddTo(tt.start)
+			if !result.Equal(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func BenchmarkParseDuration(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ParseDuration("30 minutes")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os/exec"
 	"path/filepath"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -19,57 +18,6 @@ func ResolveExecutablePath(execPath string) (string, error) {
 	return filepath.EvalSymlinks(path)
 }
 
-// ParseDuration parses duration strings in various formats
-func ParseDuration(input interface{}) (time.Duration, error) {
-	var durationStr string
-
-	// Handle both string and []string inputs
-	switch v := input.(type) {
-	case string:
-		durationStr = v
-	case []string:
-		if len(v) < 1 {
-			return 0, fmt.Errorf("invalid duration format")
-		}
-		durationStr = strings.Join(v, " ")
-	default:
-		return 0, fmt.Errorf("invalid input type")
-	}
-
-	durationStr = strings.TrimSpace(durationStr)
-
-	// Single regex to handle all formats
-	re := regexp.MustCompile(`^\s*(\d+)\s*([mhd]|minutes?|mins?|hours?|hrs?|days?|weeks?|months?)\s*$`)
-	matches := re.FindStringSubmatch(durationStr)
-
-	if matches == nil {
-		return 0, fmt.Errorf("invalid duration format")
-	}
-
-	amount, err := strconv.Atoi(matches[1])
-	if err != nil {
-		return 0, fmt.Errorf("invalid number: %s", matches[1])
-	}
-
-	unit := strings.ToLower(strings.TrimSuffix(matches[2], "s"))
-
-	// Map all unit variations to duration
-	switch unit {
-	case "m", "min", "minute":
-		return time.Duration(amount) * time.Minute, nil
-	case "h", "hr", "hour":
-		return time.Duration(amount) * time.Hour, nil
-	case "d", "day":
-		return time.Duration(amount) * 24 * time.Hour, nil
-	case "week":
-		return time.Duration(amount) * 7 * 24 * time.Hour, nil
-	case "month":
-		return time.Duration(amount) * 30 * 24 * time.Hour, nil
-	default:
-		return 0, fmt.Errorf("unsupported time unit: %s", unit)
-	}
-}
-
 // parseTimeFromString parses "HH:MM" format
 func parseTimeFromString(hourStr string) (hour, minute int, err error) {
 	parts := strings.Split(hourStr, ":")
@@ -91,23 +39,160 @@ func parseTimeFromString(hourStr string) (hour, minute int, err error) {
 }
 
 // CalculatePauseUntil calculates when to unpause based on duration and scheduled time
-func CalculatePauseUntil(duration time.Duration, scheduledHour string) (int64, error) {
-	hour, minute, err := parseTimeFromString(scheduledHour)
+func CalculatePauseUntil(duration Duration, scheduledHour string) (int64, error) {
+	until, err := CalculatePauseUntilAt(time.Now(), duration, scheduledHour)
 	if err != nil {
 		return 0, err
 	}
+	return until.Unix(), nil
+}
 
-	now := time.Now()
+// CalculatePauseUntilAt calculates when a pause starting at `now` ends. Pauses shorter
+// than a day are taken literally. Longer pauses start counting from the next scheduled
+// reminder and end at the scheduled time of day, so "pause 1 day" skips exactly one day.
+func CalculatePauseUntilAt(now time.Time, duration Duration, scheduledHour string) (time.Time, error) {
+	hour, minute, err := parseTimeFromString(scheduledHour)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if duration.IsSubDay() {
+		return now.Add(duration.Clock), nil
+	}
 
 	// Calculate next scheduled time
 	scheduledTime := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
 	if scheduledTime.Before(now) || scheduledTime.Equal(now) {
-		scheduledTime = scheduledTime.Add(24 * time.Hour)
+		// AddDate rather than 24 hours, which is off by one hour across DST changes
+		tomorrow := now.AddDate(0, 0, 1)
+		scheduledTime = time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), hour, minute, 0, 0, now.Location())
 	}
 
 	// Add the pause duration and align to the scheduled time of day
-	unpauseTime := scheduledTime.Add(duration)
-	finalUnpauseTime := time.Date(unpauseTime.Year(), unpauseTime.Month(), unpauseTime.Day(), hour, minute, 0, 0, unpauseTime.Location())
+	unpauseTime := duration.AddTo(scheduledTime)
+	return time.Date(unpauseTime.Year(), unpauseTime.Month(), unpauseTime.Day(), hour, minute, 0, 0, now.Location()), nil
+}
+
+// NextOccurrences returns the next n scheduled times after `from`, for a schedule
+// running on the given weekdays (e.g. "Monday") at scheduledHour ("HH:MM")
+func NextOccurrences(days []string, scheduledHour string, from time.Time, n int) ([]time.Time, error) {
+	hour, minute, err := parseTimeFromString(scheduledHour)
+	if err != nil {
+		return nil, err
+	}
+
+	weekdays := map[time.Weekday]bool{}
+	for _, day := range days {
+		weekday, ok := parseWeekday(strings.ToLower(day))
+		if !ok {
+			return nil, fmt.Errorf("invalid day: %s", day)
+		}
+		weekdays[weekday] = true
+	}
+	if len(weekdays) == 0 {
+		return nil, fmt.Errorf("no days scheduled")
+	}
+
+	var occurrences []time.Time
+	for i := 0; len(occurrences) < n; i++ {
+		// AddDate keeps the wall clock time across DST changes
+		day := from.AddDate(0, 0, i)
+		t := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, from.Location())
+		if weekdays[t.Weekday()] && t.After(from) {
+			occurrences = append(occurrences, t)
+		}
+	}
+	return occurrences, nil
+}
+
+// ParseUntil parses an absolute pause end such as "2026-12-27", "2026-12-27 15:30",
+// a weekday ("monday"), "tomorrow" or "next week". Dates without a time are aligned
+// to the scheduled time of day, the same way CalculatePauseUntil does.
+// Weekdays always refer to the next occurrence after today. Times that are not
+// in the future relative to now are rejected.
+func ParseUntil(input interface{}, scheduledHour string, now time.Time) (time.Time, error) {
+	var untilStr string
+
+	switch v := input.(type) {
+	case string:
+		untilStr = v
+	case []string:
+		untilStr = strings.Join(v, " ")
+	default:
+		return time.Time{}, fmt.Errorf("invalid input type")
+	}
+
+	untilStr = strings.ToLower(strings.Join(strings.Fields(untilStr), " "))
+	if untilStr == "" {
+		return time.Time{}, fmt.Errorf("no date specified")
+	}
+
+	hour, minute, err := parseTimeFromString(scheduledHour)
+	if err != nil {
+		return time.Time{}, err
+	}
+	atScheduledTime := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, now.Location())
+	}
 
-	return finalUnpauseTime.Unix(), nil
+	var until time.Time
+	switch {
+	case untilStr == "tomorrow":
+		until = atScheduledTime(now.AddDate(0, 0, 1))
+	case untilStr == "next week":
+		// Monday of next week
+		daysUntilMonday := (int(time.Monday) - int(now.Weekday()) + 7) % 7
+		if daysUntilMonday == 0 {
+			daysUntilMonday = 7
+		}
+		until = atScheduledTime(now.AddDate(0, 0, daysUntilMonday))
+	default:
+		if weekday, ok := parseWeekday(strings.TrimPrefix(untilStr, "next ")); ok {
+			days := (int(weekday) - int(now.Weekday()) + 7) % 7
+			if days == 0 {
+				days = 7
+			}
+			until = atScheduledTime(now.AddDate(0, 0, days))
+			break
+		}
+		t, hasTime, err := ParseDate(untilStr, now.Location())
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date: %s (expected YYYY-MM-DD, YYYY-MM-DD HH:MM, a weekday or 'next week')", untilStr)
+		}
+		if hasTime {
+			until = t
+		} else {
+			until = atScheduledTime(t)
+		}
+	}
+
+	if !until.After(now) {
+		return time.Time{}, fmt.Errorf("date is in the past: %s", until.Format("2006-01-02 15:04"))
+	}
+	return until, nil
+}
+
+// ParseDate parses "YYYY-MM-DD" or "YYYY-MM-DD HH:MM" in the given location.
+// hasTime reports whether a time of day was given; if not, the result is at midnight.
+func ParseDate(s string, loc *time.Location) (t time.Time, hasTime bool, err error) {
+	s = strings.Join(strings.Fields(s), " ")
+	if t, err := time.ParseInLocation("2006-01-02 15:04", s, loc); err == nil {
+		return t, true, nil
+	}
+	t, err = time.ParseInLocation("2006-01-02", s, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date: %s", s)
+	}
+	return t, false, nil
+}
+
+// parseWeekday parses full or three-letter English weekday names
+func parseWeekday(s string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			return d, true
+		}
+	}
+	return 0, false
 }
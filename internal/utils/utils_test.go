@@ -3,108 +3,154 @@ package utils
 import (
 	"testing"
 	"time"
+	_ "time/tzdata"
 )
 
-func TestParseDuration(t *testing.T) {
+func TestCalculatePauseUntil(t *testing.T) {
 	tests := []struct {
-		name     string
-		input    interface{}
-		expected time.Duration
-		hasError bool
+		name          string
+		duration      Duration
+		scheduledHour string
+		expectError   bool
 	}{
-		// String inputs
-		{"30 minutes", "30 minutes", 30 * time.Minute, false},
-		{"30m", "30m", 30 * time.Minute, false},
-		{"2 hours", "2 hours", 2 * time.Hour, false},
-		{"2h", "2h", 2 * time.Hour, false},
-		{"1 day", "1 day", 24 * time.Hour, false},
-		{"1d", "1d", 24 * time.Hour, false},
-		{"2 weeks", "2 weeks", 14 * 24 * time.Hour, false},
-		{"1 month", "1 month", 30 * 24 * time.Hour, false},
-
-		// Plural forms
-		{"5 mins", "5 mins", 5 * time.Minute, false},
-		{"3 hrs", "3 hrs", 3 * time.Hour, false},
-		{"2 days", "2 days", 2 * 24 * time.Hour, false},
-
-		// String slice inputs
-		{"slice input", []string{"30", "minutes"}, 30 * time.Minute, false},
-		{"slice input 2", []string{"2", "hours"}, 2 * time.Hour, false},
-
-		// Error cases
-		{"invalid format", "invalid", 0, true},
-		{"empty string", "", 0, true},
-		{"negative number", "-5 hours", 0, true},
-		{"no number", "hours", 0, true},
-		{"unsupported unit", "5 years", 0, true},
-		{"empty slice", []string{}, 0, true},
-		{"invalid type", 123, 0, true},
+		{"valid time", Duration{Clock: 2 * time.Hour}, "14:30", false},
+		{"midnight", Duration{Clock: time.Hour}, "00:00", false},
+		{"noon", Duration{Clock: 30 * time.Minute}, "12:00", false},
+		{"days", Duration{Days: 3}, "12:00", false},
+		{"invalid time format", Duration{Days: 1}, "25:00", false}, // parseTimeFromString doesn't validate ranges, CalculatePauseUntil will work
+		{"invalid hour", Duration{Clock: time.Hour}, "abc:30", true},
+		{"invalid minute", Duration{Clock: time.Hour}, "14:abc", true},
+		{"missing colon", Duration{Clock: time.Hour}, "1430", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := ParseDuration(tt.input)
+			result, err := CalculatePauseUntil(tt.duration, tt.scheduledHour)
 
-			if tt.hasError {
+			if tt.expectError {
 				if err == nil {
-					t.Errorf("Expected error for input %v, but got none", tt.input)
+					t.Errorf("Expected error for scheduledHour %s, but got none", tt.scheduledHour)
 				}
 				return
 			}
 
 			if err != nil {
-				t.Errorf("Unexpected error for input %v: %v", tt.input, err)
+				t.Errorf("Unexpected error for scheduledHour %s: %v", tt.scheduledHour, err)
 				return
 			}
 
-			if result != tt.expected {
-				t.Errorf("For input %v, expected %v, got %v", tt.input, tt.expected, result)
+			// Verify result is a valid timestamp in the future
+			if result <= 0 {
+				t.Errorf("Expected positive timestamp, got %d", result)
+			}
+
+			// Convert back to time and verify it's in the future
+			unpauseTime := time.Unix(result, 0)
+			if unpauseTime.Before(time.Now()) {
+				t.Errorf("Expected future time, got %v", unpauseTime)
 			}
 		})
 	}
 }
 
-func TestCalculatePauseUntil(t *testing.T) {
+func TestCalculatePauseUntilAt(t *testing.T) {
+	// Wednesday, October 14 2026 at 10:00
+	now := time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)
+
 	tests := []struct {
 		name          string
-		duration      time.Duration
+		duration      Duration
 		scheduledHour string
-		expectError   bool
+		expected      time.Time
 	}{
-		{"valid time", 2 * time.Hour, "14:30", false},
-		{"midnight", time.Hour, "00:00", false},
-		{"noon", 30 * time.Minute, "12:00", false},
-		{"invalid time format", time.Hour, "25:00", false}, // parseTimeFromString doesn't validate ranges, CalculatePauseUntil will work
-		{"invalid hour", time.Hour, "abc:30", true},
-		{"invalid minute", time.Hour, "14:abc", true},
-		{"missing colon", time.Hour, "1430", true},
+		// sub-day pauses are taken literally
+		{"30 minutes", Duration{Clock: 30 * time.Minute}, "16:00", time.Date(2026, 10, 14, 10, 30, 0, 0, time.UTC)},
+		{"2 hours", Duration{Clock: 2 * time.Hour}, "16:00", time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)},
+
+		// longer pauses count from the next reminder and align to it
+		{"1 day before todays reminder", Duration{Days: 1}, "16:00", time.Date(2026, 10, 15, 16, 0, 0, 0, time.UTC)},
+		{"1 day after todays reminder", Duration{Days: 1}, "09:00", time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)},
+		{"1 week 3 days", Duration{Days: 10}, "16:00", time.Date(2026, 10, 24, 16, 0, 0, 0, time.UTC)},
+		{"1 month", Duration{Months: 1}, "16:00", time.Date(2026, 11, 14, 16, 0, 0, 0, time.UTC)},
+		{"day and hours", Duration{Days: 1, Clock: 3 * time.Hour}, "16:00", time.Date(2026, 10, 15, 16, 0, 0, 0, time.UTC)},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := CalculatePauseUntil(tt.duration, tt.scheduledHour)
+			result, err := CalculatePauseUntilAt(now, tt.duration, tt.scheduledHour)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !result.Equal(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
 
-			if tt.expectError {
-				if err == nil {
-					t.Errorf("Expected error for scheduledHour %s, but got none", tt.scheduledHour)
+func TestCalculatePauseUntilDST(t *testing.T) {
+	// each case starts the day before a DST change in that zone, at 10:00 local time
+	zones := []struct {
+		zone string
+		day  time.Time
+	}{
+		{"Europe/Oslo", time.Date(2026, 3, 28, 10, 0, 0, 0, time.UTC)},         // spring forward
+		{"Europe/Oslo", time.Date(2026, 10, 24, 10, 0, 0, 0, time.UTC)},        // fall back
+		{"America/New_York", time.Date(2026, 3, 7, 10, 0, 0, 0, time.UTC)},     // spring forward
+		{"America/New_York", time.Date(2026, 10, 31, 10, 0, 0, 0, time.UTC)},   // fall back
+		{"Australia/Sydney", time.Date(2026, 4, 4, 10, 0, 0, 0, time.UTC)},     // fall back
+		{"Australia/Sydney", time.Date(2026, 10, 3, 10, 0, 0, 0, time.UTC)},    // spring forward
+		{"Asia/Kolkata", time.Date(2026, 3, 28, 10, 0, 0, 0, time.UTC)},        // no DST
+		{"America/Santiago", time.Date(2026, 9, 5, 10, 0, 0, 0, time.UTC)},     // spring forward at midnight
+		{"Pacific/Chatham", time.Date(2026, 9, 26, 10, 0, 0, 0, time.UTC)},     // 45 minute offset
+		{"Europe/Dublin", time.Date(2026, 3, 28, 10, 0, 0, 0, time.UTC)},       // negative DST in tzdata
+		{"Australia/Lord_Howe", time.Date(2026, 10, 3, 10, 0, 0, 0, time.UTC)}, // 30 minute DST
+	}
+
+	durations := []struct {
+		name     string
+		duration Duration
+		days     int // calendar days after the first reminder
+	}{
+		{"1 day", Duration{Days: 1}, 1},
+		{"2 days", Duration{Days: 2}, 2},
+		{"1 week", Duration{Days: 7}, 7},
+	}
+
+	for _, z := range zones {
+		loc, err := time.LoadLocation(z.zone)
+		if err != nil {
+			t.Fatalf("Failed to load zone %s: %v", z.zone, err)
+		}
+		now := time.Date(z.day.Year(), z.day.Month(), z.day.Day(), 10, 0, 0, 0, loc)
+
+		for _, d := range durations {
+			t.Run(z.zone+" "+z.day.Format("Jan 2")+" "+d.name, func(t *testing.T) {
+				result, err := CalculatePauseUntilAt(now, d.duration, "16:00")
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
 				}
-				return
-			}
 
+				expected := time.Date(now.Year(), now.Month(), now.Day()+d.days, 16, 0, 0, 0, loc)
+				if !result.Equal(expected) {
+					t.Errorf("Expected %v, got %v", expected, result)
+				}
+				if result.Hour() != 16 || result.Minute() != 0 {
+					t.Errorf("Expected the pause to end at 16:00 local time, got %s", result.Format("15:04"))
+				}
+			})
+		}
+
+		t.Run(z.zone+" "+z.day.Format("Jan 2")+" after reminder", func(t *testing.T) {
+			// the reminder already fired today, so the next one is tomorrow across the change
+			late := time.Date(now.Year(), now.Month(), now.Day(), 20, 0, 0, 0, loc)
+			result, err := CalculatePauseUntilAt(late, Duration{Days: 1}, "16:00")
 			if err != nil {
-				t.Errorf("Unexpected error for scheduledHour %s: %v", tt.scheduledHour, err)
-				return
+				t.Fatalf("Unexpected error: %v", err)
 			}
-
-			// Verify result is a valid timestamp in the future
-			if result <= 0 {
-				t.Errorf("Expected positive timestamp, got %d", result)
-			}
-
-			// Convert back to time and verify it's in the future
-			unpauseTime := time.Unix(result, 0)
-			if unpauseTime.Before(time.Now()) {
-				t.Errorf("Expected future time, got %v", unpauseTime)
+			expected := time.Date(now.Year(), now.Month(), now.Day()+2, 16, 0, 0, 0, loc)
+			if !result.Equal(expected) {
+				t.Errorf("Expected %v, got %v", expected, result)
 			}
 		})
 	}
@@ -193,15 +239,215 @@ func TestResolveExecutablePath(t *testing.T) {
 	}
 }
 
-// Benchmark tests
-func BenchmarkParseDuration(b *testing.B) {
-	for i := 0; i < b.N; i++ {
-		ParseDuration("30 minutes")
+func TestParseUntil(t *testing.T) {
+	// Wednesday, October 14 2026 at 10:00
+	now := time.Date(2026, 10, 14, 10, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name          string
+		input         interface{}
+		scheduledHour string
+		expected      time.Time
+		hasError      bool
+	}{
+		// Absolute dates are aligned to the scheduled time
+		{"date", "2026-12-27", "14:30", time.Date(2026, 12, 27, 14, 30, 0, 0, time.Local), false},
+		{"date later today", "2026-10-14", "14:30", time.Date(2026, 10, 14, 14, 30, 0, 0, time.Local), false},
+		{"date with time", "2026-12-27 15:30", "14:30", time.Date(2026, 12, 27, 15, 30, 0, 0, time.Local), false},
+		{"slice input", []string{"2026-12-27", "15:30"}, "14:30", time.Date(2026, 12, 27, 15, 30, 0, 0, time.Local), false},
+		{"extra whitespace", "  2026-12-27   15:30 ", "14:30", time.Date(2026, 12, 27, 15, 30, 0, 0, time.Local), false},
+
+		// Weekdays refer to the next occurrence
+		{"monday", "monday", "14:30", time.Date(2026, 10, 19, 14, 30, 0, 0, time.Local), false},
+		{"capitalized", "Friday", "09:00", time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local), false},
+		{"short name", "thu", "09:00", time.Date(2026, 10, 15, 9, 0, 0, 0, time.Local), false},
+		{"same weekday is next week", "wednesday", "14:30", time.Date(2026, 10, 21, 14, 30, 0, 0, time.Local), false},
+		{"next weekday", "next tuesday", "14:30", time.Date(2026, 10, 20, 14, 30, 0, 0, time.Local), false},
+
+		// Relative days
+		{"tomorrow", "tomorrow", "14:30", time.Date(2026, 10, 15, 14, 30, 0, 0, time.Local), false},
+		{"next week", "next week", "14:30", time.Date(2026, 10, 19, 14, 30, 0, 0, time.Local), false},
+
+		// Error cases
+		{"past date", "2026-01-01", "14:30", time.Time{}, true},
+		{"earlier today", "2026-10-14", "09:00", time.Time{}, true},
+		{"past time today", "2026-10-14 09:59", "14:30", time.Time{}, true},
+		{"now", "2026-10-14 10:00", "14:30", time.Time{}, true},
+		{"invalid date", "2026-13-01", "14:30", time.Time{}, true},
+		{"invalid time", "2026-12-27 25:00", "14:30", time.Time{}, true},
+		{"garbage", "someday", "14:30", time.Time{}, true},
+		{"empty", "", "14:30", time.Time{}, true},
+		{"empty slice", []string{}, "14:30", time.Time{}, true},
+		{"invalid type", 123, "14:30", time.Time{}, true},
+		{"invalid scheduled hour", "monday", "abc", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseUntil(tt.input, tt.scheduledHour, now)
+
+			if tt.hasError {
+				if err == nil {
+					t.Errorf("Expected error for input %v, but got %v", tt.input, result)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error for input %v: %v", tt.input, err)
+				return
+			}
+
+			if !result.Equal(tt.expected) {
+				t.Errorf("For input %v, expected %v, got %v", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseUntilNextWeekOnMonday(t *testing.T) {
+	monday := time.Date(2026, 10, 12, 8, 0, 0, 0, time.Local)
+
+	result, err := ParseUntil("next week", "14:30", monday)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := time.Date(2026, 10, 19, 14, 30, 0, 0, time.Local)
+	if !result.Equal(expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	// crossing into a new month and year
+	newYearsEve := time.Date(2026, 12, 31, 8, 0, 0, 0, time.Local)
+	result, err = ParseUntil("next week", "14:30", newYearsEve)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected = time.Date(2027, 1, 4, 14, 30, 0, 0, time.Local)
+	if !result.Equal(expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Time
+		hasTime  bool
+		hasError bool
+	}{
+		{"date", "2026-12-27", time.Date(2026, 12, 27, 0, 0, 0, 0, time.UTC), false, false},
+		{"date with time", "2026-12-27 15:30", time.Date(2026, 12, 27, 15, 30, 0, 0, time.UTC), true, false},
+		{"extra whitespace", " 2026-12-27  15:30", time.Date(2026, 12, 27, 15, 30, 0, 0, time.UTC), true, false},
+		{"invalid month", "2026-13-01", time.Time{}, false, true},
+		{"invalid time", "2026-12-27 24:00", time.Time{}, false, true},
+		{"wrong format", "27.12.2026", time.Time{}, false, true},
+		{"empty", "", time.Time{}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, hasTime, err := ParseDate(tt.input, time.UTC)
+
+			if tt.hasError {
+				if err == nil {
+					t.Errorf("Expected error for input %s, but got none", tt.input)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error for input %s: %v", tt.input, err)
+				return
+			}
+
+			if !result.Equal(tt.expected) {
+				t.Errorf("For input %s, expected %v, got %v", tt.input, tt.expected, result)
+			}
+			if hasTime != tt.hasTime {
+				t.Errorf("For input %s, expected hasTime %v, got %v", tt.input, tt.hasTime, hasTime)
+			}
+		})
 	}
 }
 
+func TestNextOccurrences(t *testing.T) {
+	// Wednesday, October 14 2026 at 10:00
+	now := time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		days          []string
+		scheduledHour string
+		n             int
+		expected      []time.Time
+		hasError      bool
+	}{
+		{
+			name:          "later today counts",
+			days:          []string{"Wednesday", "Friday"},
+			scheduledHour: "16:00",
+			n:             3,
+			expected: []time.Time{
+				time.Date(2026, 10, 14, 16, 0, 0, 0, time.UTC),
+				time.Date(2026, 10, 16, 16, 0, 0, 0, time.UTC),
+				time.Date(2026, 10, 21, 16, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:          "earlier today does not",
+			days:          []string{"Wednesday"},
+			scheduledHour: "09:00",
+			n:             2,
+			expected: []time.Time{
+				time.Date(2026, 10, 21, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, 10, 28, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:          "zero",
+			days:          []string{"Monday"},
+			scheduledHour: "09:00",
+			n:             0,
+			expected:      nil,
+		},
+		{name: "no days", days: []string{}, scheduledHour: "09:00", n: 1, hasError: true},
+		{name: "invalid day", days: []string{"Funday"}, scheduledHour: "09:00", n: 1, hasError: true},
+		{name: "invalid hour", days: []string{"Monday"}, scheduledHour: "nine", n: 1, hasError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NextOccurrences(tt.days, tt.scheduledHour, now, tt.n)
+
+			if tt.hasError {
+				if err == nil {
+					t.Errorf("Expected error, but got %v", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(result) != len(tt.expected) {
+				t.Fatalf("Expected %d occurrences, got %v", len(tt.expected), result)
+			}
+			for i := range result {
+				if !result[i].Equal(tt.expected[i]) {
+					t.Errorf("Occurrence %d: expected %v, got %v", i, tt.expected[i], result[i])
+				}
+			}
+		})
+	}
+}
+
+// Benchmark tests
 func BenchmarkCalculatePauseUntil(b *testing.B) {
-	duration := 2 * time.Hour
+	duration := Duration{Days: 2}
 	for i := 0; i < b.N; i++ {
 		CalculatePauseUntil(duration, "14:30")
 	}